@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+)
+
+// GameOver is a Mode which shows that the hero has died. Any key quits.
+type GameOver struct {
+	*hjkl.Anchored
+	Panel *hjkl.PanelWidget
+}
+
+func NewGameOver(turns int) *GameOver {
+	text := hjkl.NewTextWidget(hjkl.Vector{}, hjkl.Vector{},
+		hjkl.TextFg("You die...\n", hjkl.ColorLightRed),
+		hjkl.Text(fmt.Sprintf("You survived %d turns.\n\nPress any key.", turns)),
+	)
+	text.Align = hjkl.AlignCenter
+	panel := hjkl.NewPanelWidget(hjkl.Vector{}, hjkl.Vector{}, "Game Over", text)
+
+	// The panel is centered, regardless of the screen size.
+	layout := hjkl.Layout{
+		Left:   hjkl.Pct(50).Plus(-15),
+		Top:    hjkl.Pct(50).Plus(-3),
+		Right:  hjkl.Pct(50).Plus(15),
+		Bottom: hjkl.Pct(50).Plus(3),
+	}
+	return &GameOver{hjkl.NewAnchored(panel, layout), panel}
+}
+
+func (m *GameOver) Update(s *hjkl.Stack, ins []hjkl.Input) error {
+	for _, in := range ins {
+		if in.Kind == hjkl.InputKey {
+			return hjkl.Termination
+		}
+	}
+	return nil
+}
+
+func (m *GameOver) Draw(c hjkl.Canvas) {
+	for y := 0; y < m.Panel.Size.Y; y++ {
+		for x := 0; x < m.Panel.Size.X; x++ {
+			c.Blit(m.Panel.Pos.Add(hjkl.Vec(x, y)), hjkl.Ch(' '))
+		}
+	}
+	m.Panel.Draw(c)
+}
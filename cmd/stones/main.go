@@ -1,71 +1,415 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
 	"github.com/jefflund/stones/pkg/hjkl"
 	"github.com/jefflund/stones/pkg/hjkl/clock"
+	"github.com/jefflund/stones/pkg/hjkl/fov"
 	"github.com/jefflund/stones/pkg/hjkl/gen"
 	"github.com/jefflund/stones/pkg/hjkl/rand"
+	"github.com/jefflund/stones/pkg/hjkl/save"
 	"github.com/jefflund/stones/pkg/rpg"
 )
 
 type Game struct {
 	hjkl.Screen
-	Hero  *hjkl.Mob
-	Level []*hjkl.Tile
-	Clock *clock.Clock[*hjkl.Mob]
+	Camera   *hjkl.CameraWidget
+	Messages *hjkl.MessageWidget
+	Health   *hjkl.BarWidget
+	Statuses *hjkl.TextWidget
+	Log      *hjkl.MessageLog
+	Hero     *hjkl.Mob
+	Stats    *rpg.Character
+	Level    *hjkl.Level
+	Mobs     *hjkl.Roster
+	Clock    *clock.Clock[*hjkl.Mob]
+	Turns    *hjkl.Turns
+	Effects  *rpg.Effects
+	Save     string
+	Turn     int
+
+	// now is the Clock time the Effects have caught up to.
+	now int
 }
 
-func NewGame() *Game {
-	cols, rows := 80, 24
+func NewGame(savePath string) *Game {
+	cols, rows := 160, 60
 
-	level := gen.GenTileGrid(cols, rows, rpg.ForestTile)
-	gen.GenFence(level, rpg.ForestFence)
+	level := gen.GenLevel(cols, rows, rpg.ForestTile)
+	gen.GenFence(level.Tiles, rpg.ForestFence)
 
 	clock := clock.New[*hjkl.Mob]()
 
 	hero := rpg.NewHero()
-	hjkl.PlaceMob(hero, rand.FilteredChoice(level, hjkl.OpenTile))
+	memory := fov.NewMemory(8)
+	hero.Components.Add(memory)
+	level.Mobs.Spawn(hero, rand.FilteredChoice(level.Tiles, hjkl.OpenTile))
+	memory.Update(hero.Pos)
+	clock.Schedule(hero, 0)
+
+	spawns := rpg.SpawnTable(rpg.Bestiary)
+	for i := 1; i <= 150; i++ {
+		mob := spawns.Draw().New()
+		level.Mobs.Spawn(mob, rand.FilteredChoice(level.Tiles, hjkl.OpenTile))
+		clock.Schedule(mob, rand.Range(1, hjkl.CostMove))
+	}
+
+	return newGame(hero, level, clock, savePath)
+}
 
-	for i := 1; i <= 30; i++ {
-		mob := rand.Choice(rpg.Bestiary).New()
-		hjkl.PlaceMob(mob, rand.FilteredChoice(level, hjkl.OpenTile))
-		clock.Schedule(mob, i%10)
+func LoadGame(savePath string) (*Game, error) {
+	f, err := os.Open(savePath)
+	if err != nil {
+		return nil, err
 	}
+	defer f.Close()
 
+	state, err := save.Load(f)
+	if err != nil {
+		return nil, err
+	}
+	if state.Level == nil {
+		return nil, fmt.Errorf("%s does not contain a level", savePath)
+	}
+	rand.SetState(state.Rand)
+
+	return newGame(state.Player, state.Level, state.Clock, savePath), nil
+}
+
+func newGame(hero *hjkl.Mob, level *hjkl.Level, clock *clock.Clock[*hjkl.Mob], savePath string) *Game {
+	// Every Mob on the level is live, and leaves the clock once removed.
+	mobs := level.Mobs
+	mobs.OnRemove = append(mobs.OnRemove, clock.Unschedule)
+
+	// Any Status on a loaded Mob picks up where it left off.
+	effects := rpg.NewEffects()
+	for _, m := range mobs.Mobs() {
+		effects.Track(m)
+	}
+	mobs.OnRemove = append(mobs.OnRemove, effects.Remove)
+	level.Systems = append(level.Systems, effects)
+
+	tiles := hjkl.NewLevelCamera(hjkl.Vector{}, hjkl.Vector{}, level, hero)
+	tiles.DeadZone = hjkl.Vec(8, 4)
+	var stats *rpg.Character
+	for _, c := range hero.Components {
+		switch c := c.(type) {
+		case *fov.Memory:
+			tiles.View = c
+		case *rpg.Character:
+			stats = c
+		}
+	}
+	log := hjkl.NewMessageLog(100)
+	log.Add(0, "Welcome to the forest.")
+	messages := hjkl.NewMessageWidget(hjkl.Vector{}, hjkl.Vector{}, log)
+
+	health := hjkl.NewBarWidget(hjkl.Vector{}, hjkl.Vector{}, "HP ", hjkl.ColorRed, hjkl.ColorLightBlack)
+	statuses := hjkl.NewTextWidget(hjkl.Vector{}, hjkl.Vector{})
+
+	// The map fills the screen, except for a status line and a few lines of
+	// messages below.
+	mapLayout, statusLayout, logLayout := hjkl.Fill, hjkl.Fill, hjkl.Fill
+	mapLayout.Bottom = hjkl.Pct(100).Plus(-4)
+	statusLayout.Top, statusLayout.Bottom = hjkl.Pct(100).Plus(-4), hjkl.Pct(100).Plus(-3)
+	statusLayout.Right = hjkl.Abs(20)
+	effectsLayout := statusLayout
+	effectsLayout.Left, effectsLayout.Right = hjkl.Abs(21), hjkl.Pct(100)
+	logLayout.Top = hjkl.Pct(100).Plus(-3)
 	screen := hjkl.Screen{
-		hjkl.NewTilesWidget(hjkl.Vec(0, 0), hjkl.Vec(cols, rows), level),
+		hjkl.NewAnchored(tiles, mapLayout),
+		hjkl.NewAnchored(health, statusLayout),
+		hjkl.NewAnchored(statuses, effectsLayout),
+		hjkl.NewAnchored(messages, logLayout),
+	}
+
+	g := &Game{
+		Screen:   screen,
+		Camera:   tiles,
+		Messages: messages,
+		Health:   health,
+		Statuses: statuses,
+		Log:      log,
+		Hero:     hero,
+		Stats:    stats,
+		Level:    level,
+		Mobs:     mobs,
+		Clock:    clock,
+		Turns:    hjkl.NewTurns(clock, hero),
+		Effects:  effects,
+		Save:     savePath,
+	}
+	level.Events = hjkl.SinkFunc(g.Report)
+	return g
+}
+
+// Report logs any published Event which describes itself, provided the hero
+// is involved or can see it happen.
+func (g *Game) Report(v hjkl.Event) {
+	msg, ok := v.(fmt.Stringer)
+	if !ok {
+		return
+	}
+	var attacker, defender *hjkl.Mob
+	switch v := v.(type) {
+	case *rpg.Hit:
+		attacker, defender = v.Attacker, v.Defender
+	case *rpg.Miss:
+		attacker, defender = v.Attacker, v.Defender
+	case *rpg.Kill:
+		attacker, defender = v.Attacker, v.Defender
+	case *rpg.StatusStart:
+		defender = v.Mob
+	case *rpg.StatusEnd:
+		defender = v.Mob
 	}
+	involved := attacker == g.Hero || defender == g.Hero
+	if !involved && (defender == nil || defender.Pos == nil || !g.visible(defender.Pos)) {
+		return
+	}
+	g.Log.Add(g.Turn, msg.String())
+}
 
-	return &Game{screen, hero, level, clock}
+func (g *Game) visible(t *hjkl.Tile) bool {
+	return g.Camera.View == nil || g.Camera.View.Visible(t)
+}
+
+// statusColors gives the color each Status is shown in.
+var statusColors = map[rpg.StatusKind]hjkl.Color{
+	rpg.StatusPoisoned:     hjkl.ColorGreen,
+	rpg.StatusRegenerating: hjkl.ColorLightRed,
+	rpg.StatusHasted:       hjkl.ColorYellow,
+	rpg.StatusSlowed:       hjkl.ColorBlue,
+}
+
+// SaveGame writes the game to a temporary file beside the save, and only then
+// replaces the save with it, so a failed write never destroys the old save.
+func (g *Game) SaveGame() (err error) {
+	if g.Save == "" {
+		return nil
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(g.Save), filepath.Base(g.Save)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	err = save.Save(f, &save.State{
+		Level:  g.Level,
+		Player: g.Hero,
+		Clock:  g.Clock,
+		Rand:   rand.State(),
+	})
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), g.Save)
 }
 
-func (g *Game) Update(ks []hjkl.Key) error {
-	for _, k := range ks {
-		switch k {
-		case hjkl.KeyEsc, hjkl.KeyCtrlC:
+func (g *Game) Draw(c hjkl.Canvas) {
+	if g.Stats != nil {
+		g.Health.Set(g.Stats.Health, g.Stats.MaxHealth)
+	}
+	var spans []hjkl.Span
+	for i, s := range hjkl.Get(g.Hero, &rpg.StatusQuery{}) {
+		if i > 0 {
+			spans = append(spans, hjkl.Text(" "))
+		}
+		spans = append(spans, hjkl.TextFg(s.String(), statusColors[s.Kind]))
+	}
+	g.Statuses.SetText(spans...)
+	g.Screen.Draw(c)
+}
+
+func (g *Game) Update(s *hjkl.Stack, ins []hjkl.Input) error {
+	// Let the world catch up, in case it is not yet the hero's turn.
+	g.RunWorld()
+
+inputs:
+	for _, in := range ins {
+		// A dead hero gets no more input, just the game over screen.
+		if !g.Mobs.Contains(g.Hero) {
+			break
+		}
+		if in.Kind == hjkl.InputMouse {
+			switch in.Mouse {
+			case hjkl.MouseWheelUp:
+				g.Messages.ScrollBy(1)
+			case hjkl.MouseWheelDown:
+				g.Messages.ScrollBy(-1)
+			}
+		}
+		if in.Kind != hjkl.InputKey {
+			continue
+		}
+		switch in.Key {
+		case hjkl.KeyEsc:
+			s.Push(hjkl.NewConfirmMode(hjkl.Vec(0, 0), hjkl.Vec(s.Size().X, 1), "Save and quit?", func(yes bool) error {
+				if !yes {
+					return nil
+				}
+				if err := g.SaveGame(); err != nil {
+					return err
+				}
+				return hjkl.Termination
+			}))
+			break inputs
+		case hjkl.KeyCtrlC:
 			return hjkl.Termination
+		case 'x':
+			if g.Hero.Pos == nil {
+				continue
+			}
+			s.Push(hjkl.NewLookMode(hjkl.Vec(0, 0), hjkl.Vec(s.Size().X, 1), g.Camera, g.Hero.Pos, func(t *hjkl.Tile, ok bool) error {
+				if ok {
+					g.Log.Add(g.Turn, hjkl.Describe(t))
+				}
+				return nil
+			}))
+			break inputs
+		case '.', '5':
+			g.Act(hjkl.CostWait)
 		default:
-			if delta, ok := hjkl.VIKeyDirs[k]; ok {
-				g.Hero.Handle(&hjkl.Move{Delta: delta})
+			if delta, ok := hjkl.KeyDir(in.Key); ok && g.Turns.PlayerTurn() {
+				move := &hjkl.Move{Delta: delta}
+				g.Hero.Handle(move)
+				g.Act(move.Cost)
 			}
 		}
 	}
 
-	for _, m := range g.Clock.Tick() {
-		if m.Pos == nil {
+	if !g.Mobs.Contains(g.Hero) {
+		return g.GameOver(s)
+	}
+	return nil
+}
+
+// Act ends the hero's turn after an action with the given cost, then lets the
+// world run until it is the hero's turn again. Actions which cost nothing,
+// such as walking into a tree, leave it the hero's turn.
+func (g *Game) Act(cost int) {
+	if cost <= 0 || !g.Turns.PlayerTurn() {
+		return
+	}
+	g.Turns.End(g.Hero, cost)
+	g.Turn++
+	g.RunWorld()
+}
+
+// RunWorld has each Mob take its turn until it is the hero's turn, or the
+// hero is dead. Any Status keeps time with the turns.
+func (g *Game) RunWorld() {
+	// The hero stays put until the world is done, so every Mob can share
+	// the same Pursuit.
+	pursuit := &rpg.Pursuit{Target: g.Hero}
+	for {
+		m := g.Turns.Next()
+		g.Effects.Advance(g.Clock.Now() - g.now)
+		g.now = g.Clock.Now()
+		if m == nil || m == g.Hero {
+			return
+		}
+		// A Status may have killed the Mob just as its turn came up.
+		if !g.Mobs.Contains(m) {
 			continue
 		}
 
-		delta := rand.Choice(hjkl.CompassDirs)
-		m.Handle(&hjkl.Move{Delta: delta})
-		g.Clock.Schedule(m, rand.Range(10, 50))
+		turn := &rpg.TakeTurn{Pursuit: pursuit}
+		m.Handle(turn)
+		// A Mob which did nothing still used up its turn.
+		if g.Mobs.Contains(m) {
+			g.Turns.End(m, max(turn.Cost, hjkl.CostWait))
+		}
 	}
+}
 
+// GameOver deletes any save, since death is permanent, and then shows the
+// game over screen.
+func (g *Game) GameOver(s *hjkl.Stack) error {
+	if g.Save != "" {
+		if err := os.Remove(g.Save); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	s.Push(NewGameOver(g.Turn))
 	return nil
 }
 
 func main() {
-	if err := hjkl.Run(NewGame()); err != nil {
+	savePath := flag.String("save", "stones.sav", "path of the save file")
+	recordPath := flag.String("record", "", "record a new game to the given file")
+	replayPath := flag.String("replay", "", "replay a game from the given file")
+	tps := flag.Int("tps", 20, "ticks per second, or 0 for as fast as possible")
+	ansi := flag.Bool("ansi", false, "use raw ANSI escape sequences instead of termbox")
+	flag.Parse()
+
+	var term hjkl.Terminal = hjkl.TermboxTerminal{}
+	if *ansi {
+		term = hjkl.NewANSITerminal()
+	}
+	opts := []hjkl.RunOption{hjkl.WithTPS(*tps), hjkl.WithTerm(term)}
+
+	var game *Game
+	switch {
+	case *replayPath != "":
+		// Replays start a fresh game from the recorded seed, and must not
+		// disturb any existing save.
+		replay, err := LoadReplay(*replayPath, term)
+		if err != nil {
+			panic(err)
+		}
+		rand.Seed(replay.Seed)
+		game = NewGame("")
+		opts = append(opts, hjkl.WithTerm(replay))
+	case *recordPath != "":
+		// Recordings always start a fresh game, since the save is not part
+		// of the recording.
+		f, err := os.Create(*recordPath)
+		if err != nil {
+			panic(err)
+		}
+		defer f.Close()
+		opts = append(opts, hjkl.WithRecord(f, rand.State()))
+		game = NewGame(*savePath)
+	default:
+		var err error
+		game, err = LoadGame(*savePath)
+		if errors.Is(err, fs.ErrNotExist) {
+			game, err = NewGame(*savePath), nil
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if err := hjkl.Run(hjkl.NewStack(game), opts...); err != nil {
 		panic(err)
 	}
 }
+
+func LoadReplay(replayPath string, display hjkl.Terminal) (*hjkl.Replay, error) {
+	f, err := os.Open(replayPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return hjkl.LoadReplay(f, display)
+}
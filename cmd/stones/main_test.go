@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/rand"
+	"github.com/jefflund/stones/pkg/rpg"
+)
+
+func TestGame(t *testing.T) {
+	rand.Seed(0xBAAAAAAD)
+	game := NewGame("")
+
+	term := hjkl.NewMemTerminal(hjkl.Vec(80, 24))
+	for _, k := range "hjklyubn" {
+		term.Send(hjkl.Key(k))
+		term.Send()
+	}
+	if err := hjkl.Run(hjkl.NewStack(game), hjkl.WithTerm(term), hjkl.WithTPS(0)); err != nil {
+		t.Fatal("Run gave error", err)
+	}
+
+	if got := term.At(game.Camera.ToScreen(game.Hero.Pos.Offset)); got.Ch != '@' {
+		t.Errorf("Hero drawn as %q", got.Ch)
+	}
+	lines := strings.Split(term.String(), "\n")
+	if !strings.Contains(lines[20], fmt.Sprintf("HP %d/10", game.Stats.Health)) {
+		t.Errorf("Status line drawn as %q", lines[20])
+	}
+	latest := game.Log.Messages[len(game.Log.Messages)-1].String()
+	if log := strings.Join(lines[21:], "\n"); !strings.Contains(log, latest) {
+		t.Errorf("Message log drawn as %q", log)
+	}
+	blank := 0
+	for _, row := range term.Grid() {
+		for _, g := range row {
+			if g.Ch == 0 {
+				blank++
+			}
+		}
+	}
+	if blank == 0 {
+		t.Error("Game revealed Tile the hero has never seen")
+	}
+}
+
+func TestGame_Quit(t *testing.T) {
+	rand.Seed(0xBAAAAAAD)
+	game := NewGame("")
+
+	term := hjkl.NewMemTerminal(hjkl.Vec(80, 24))
+	term.Send(hjkl.KeyEsc)
+	term.Send('n')
+	term.Send(hjkl.KeyEsc)
+	term.Send('y')
+	term.Send()
+	term.Send()
+	if err := hjkl.Run(hjkl.NewStack(game), hjkl.WithTerm(term), hjkl.WithTPS(0)); err != nil {
+		t.Fatal("Run gave error", err)
+	}
+
+	if term.Frames() != 3 {
+		t.Errorf("Game ran for %d frames instead of quitting", term.Frames())
+	}
+	if got := strings.Split(term.String(), "\n")[0]; !strings.HasPrefix(got, "Save and quit? (y/n)") {
+		t.Errorf("Prompt drawn as %q", got)
+	}
+}
+
+func TestGame_SaveGame(t *testing.T) {
+	rand.Seed(0xBAAAAAAD)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "stones.sav")
+	if err := os.WriteFile(path, []byte("old save"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	game := NewGame(path)
+	if err := game.SaveGame(); err != nil {
+		t.Fatal("SaveGame gave error", err)
+	}
+	loaded, err := LoadGame(path)
+	if err != nil {
+		t.Fatal("LoadGame gave error", err)
+	}
+	if loaded.Hero.Pos.Offset != game.Hero.Pos.Offset {
+		t.Error("LoadGame gave a different hero position")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("SaveGame left %d files instead of 1", len(entries))
+	}
+
+	// A save which cannot even be started gives an error.
+	game.Save = filepath.Join(dir, "missing", "stones.sav")
+	if err := game.SaveGame(); err == nil {
+		t.Error("SaveGame to a missing directory gave no error")
+	}
+}
+
+func TestGame_Look(t *testing.T) {
+	rand.Seed(0xBAAAAAAD)
+	game := NewGame("")
+
+	term := hjkl.NewMemTerminal(hjkl.Vec(80, 24))
+	term.Send('x')
+	term.Send()
+	term.Send(hjkl.KeyEnter)
+	if err := hjkl.Run(hjkl.NewStack(game), hjkl.WithTerm(term), hjkl.WithTPS(0)); err != nil {
+		t.Fatal("Run gave error", err)
+	}
+
+	want := hjkl.Describe(game.Hero.Pos)
+	if got := game.Log.Messages[len(game.Log.Messages)-1].Text; got != want {
+		t.Errorf("Look logged %q instead of %q", got, want)
+	}
+}
+
+func TestGame_Combat(t *testing.T) {
+	rand.Seed(0xBAAAAAAD)
+	game := NewGame("")
+
+	// Put a boar right next to the hero, clearing the way if needed.
+	east, _ := game.Hero.Pos.Neighbor(hjkl.Vec(1, 0))
+	if east.Occupant != nil {
+		game.Mobs.Remove(east.Occupant)
+	}
+	east.Pass = true
+	boar := rpg.Bestiary[1].New()
+	game.Mobs.Spawn(boar, east)
+
+	term := hjkl.NewMemTerminal(hjkl.Vec(80, 24))
+	term.Send('l')
+	term.Send('l')
+	if err := hjkl.Run(hjkl.NewStack(game), hjkl.WithTerm(term), hjkl.WithTPS(0)); err != nil {
+		t.Fatal("Run gave error", err)
+	}
+
+	var texts []string
+	for _, m := range game.Log.Messages {
+		texts = append(texts, m.String())
+	}
+	log := strings.Join(texts, "\n")
+	if !strings.Contains(log, "You hit the boar. (x2)") || !strings.Contains(log, "You kill the boar.") {
+		t.Errorf("Combat logged %q", log)
+	}
+	if boar.Pos != nil || game.Mobs.Contains(boar) {
+		t.Error("Boar survived two hits")
+	}
+	if east.Face.Ch != rpg.CorpseCh || hjkl.Describe(east) != "You see the corpse." {
+		t.Error("Boar did not leave a corpse")
+	}
+}
+
+func TestGame_Death(t *testing.T) {
+	// Keys pressed along with the fatal wait must not hide the game over.
+	for _, keys := range [][]hjkl.Key{{'.'}, {'.', hjkl.KeyEsc}, {'.', 'x'}} {
+		rand.Seed(0xBAAAAAAD)
+		game := NewGame("")
+		game.Stats.Health = 1
+
+		// Put a bear right next to the hero, ready to attack.
+		east, _ := game.Hero.Pos.Neighbor(hjkl.Vec(1, 0))
+		if east.Occupant != nil {
+			game.Mobs.Remove(east.Occupant)
+		}
+		east.Pass = true
+		bear := rpg.Bestiary[0].New()
+		game.Mobs.Spawn(bear, east)
+		game.Clock.Schedule(bear, 1)
+
+		// The bear only acts once the hero has spent their turn waiting.
+		term := hjkl.NewMemTerminal(hjkl.Vec(80, 24))
+		term.Send(keys...)
+		term.Send()
+		term.Send('q')
+		term.Send()
+		if err := hjkl.Run(hjkl.NewStack(game), hjkl.WithTerm(term), hjkl.WithTPS(0)); err != nil {
+			t.Fatal("Run gave error", err)
+		}
+
+		if game.Mobs.Contains(game.Hero) || game.Hero.Pos != nil {
+			t.Errorf("Hero survived the bear with keys %q", keys)
+		}
+		if !strings.Contains(term.String(), "You die...") {
+			t.Errorf("Game over screen not drawn with keys %q:\n%s", keys, term.String())
+		}
+		if term.Frames() != 2 {
+			t.Errorf("Game ran for %d frames instead of quitting with keys %q", term.Frames(), keys)
+		}
+	}
+}
+
+func TestGame_Poison(t *testing.T) {
+	rand.Seed(0xBAAAAAAD)
+	game := NewGame("")
+	game.Effects.Apply(game.Hero, rpg.Poison(1, 3))
+
+	term := hjkl.NewMemTerminal(hjkl.Vec(80, 24))
+	term.Send('.')
+	if err := hjkl.Run(hjkl.NewStack(game), hjkl.WithTerm(term), hjkl.WithTPS(0)); err != nil {
+		t.Fatal("Run gave error", err)
+	}
+	if !strings.Contains(term.String(), "poisoned") {
+		t.Errorf("Status line not drawn:\n%s", term.String())
+	}
+
+	health := game.Stats.Health
+	term.Send('.')
+	term.Send('.')
+	term.Send('.')
+	if err := hjkl.Run(hjkl.NewStack(game), hjkl.WithTerm(term), hjkl.WithTPS(0)); err != nil {
+		t.Fatal("Run gave error", err)
+	}
+
+	var texts []string
+	for _, m := range game.Log.Messages {
+		texts = append(texts, m.String())
+	}
+	log := strings.Join(texts, "\n")
+	if !strings.Contains(log, "You are poisoned.") || !strings.Contains(log, "You are no longer poisoned.") {
+		t.Errorf("Poison logged %q", log)
+	}
+	if game.Stats.Health > health-2 {
+		t.Error("Poison failed to hurt the hero")
+	}
+	if len(hjkl.Get(game.Hero, &rpg.StatusQuery{})) != 0 {
+		t.Error("Poison failed to expire")
+	}
+}
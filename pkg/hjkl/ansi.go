@@ -0,0 +1,413 @@
+package hjkl
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// ANSITerminal is a Terminal implemented directly with ANSI escape sequences
+// on a raw mode TTY, without any dependency on termbox. Only the cells which
+// changed since the previous Flush are rewritten. When the TTY is resized, an
+// InputResize is sent and the buffers are resized on the next Clear.
+type ANSITerminal struct {
+	In  *os.File
+	Out io.Writer
+
+	// TrueColor emits 24-bit SGR color sequences using Color.RGB instead of
+	// the 256 color SGR sequences.
+	TrueColor bool
+
+	raw    *rawState
+	size   Vector
+	front  []Glyph
+	back   []Glyph
+	stop   chan struct{}
+	winch  chan os.Signal
+	buf    bytes.Buffer
+	cursor Vector
+	sgr    string
+
+	// pending is the size from the latest resize, set by the input goroutine
+	// and applied by Clear, or the zero Vector if there is none.
+	mu      sync.Mutex
+	pending Vector
+}
+
+// NewANSITerminal creates an ANSITerminal using stdin and stdout.
+func NewANSITerminal() *ANSITerminal {
+	return &ANSITerminal{In: os.Stdin, Out: os.Stdout}
+}
+
+// Blit places a Glyph into the back buffer.
+func (t *ANSITerminal) Blit(v Vector, g Glyph) {
+	if 0 <= v.X && v.X < t.size.X && 0 <= v.Y && v.Y < t.size.Y {
+		t.back[v.Y*t.size.X+v.X] = g
+	}
+}
+
+// Init puts the TTY in raw mode and switches to the alternate screen.
+func (t *ANSITerminal) Init() error {
+	raw, err := enableRaw(t.In.Fd())
+	if err != nil {
+		return err
+	}
+	t.raw = raw
+
+	size, err := termSize(t.In.Fd())
+	if err != nil {
+		disableRaw(t.In.Fd(), t.raw)
+		return err
+	}
+	t.resize(size)
+	t.winch = make(chan os.Signal, 1)
+	notifyResize(t.winch)
+
+	// Alternate screen, hidden cursor, SGR mouse reporting, cleared screen.
+	_, err = io.WriteString(t.Out, "\x1b[?1049h\x1b[?25l\x1b[?1000h\x1b[?1006h\x1b[0m\x1b[2J")
+	return err
+}
+
+// Size gets the current size of the TTY in cells.
+func (t *ANSITerminal) Size() Vector {
+	t.applyResize()
+	return t.size
+}
+
+// applyResize resizes the buffers if the TTY was resized since the last call.
+func (t *ANSITerminal) applyResize() {
+	t.mu.Lock()
+	size := t.pending
+	t.pending = Vector{}
+	t.mu.Unlock()
+	if size != (Vector{}) && size != t.size {
+		t.resize(size)
+	}
+}
+
+// resize reallocates the buffers so the next Flush redraws every cell.
+func (t *ANSITerminal) resize(size Vector) {
+	t.size = size
+	t.back = make([]Glyph, size.X*size.Y)
+	t.front = make([]Glyph, size.X*size.Y)
+	for i := range t.front {
+		// An invalid rune ensures every cell differs from the back buffer.
+		t.front[i] = Glyph{Ch: -1}
+	}
+	t.cursor = Vec(-1, -1)
+	t.sgr = ""
+}
+
+// Input gets an input channel and starts a goroutine to fill it.
+func (t *ANSITerminal) Input() chan Input {
+	input := make(chan Input)
+	t.stop = make(chan struct{})
+	go func(stop chan struct{}, winch chan os.Signal) {
+		defer close(input)
+		buf := make([]byte, 256)
+		for {
+			// Since raw mode sets a read timeout, Read returns periodically
+			// even without input, giving a chance to check for Done.
+			n, err := t.In.Read(buf)
+			if err != nil && err != io.EOF {
+				return
+			}
+			inputs := decodeInputs(buf[:n])
+			select {
+			case <-winch:
+				if size, err := termSize(t.In.Fd()); err == nil {
+					t.mu.Lock()
+					t.pending = size
+					t.mu.Unlock()
+					inputs = append(inputs, Input{Kind: InputResize, Pos: size})
+				}
+			default:
+			}
+			for _, in := range inputs {
+				select {
+				case input <- in:
+				case <-stop:
+					return
+				}
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}(t.stop, t.winch)
+	return input
+}
+
+// Done stops the input goroutine and restores the TTY.
+func (t *ANSITerminal) Done() {
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+	if t.winch != nil {
+		signal.Stop(t.winch)
+		t.winch = nil
+	}
+	io.WriteString(t.Out, "\x1b[0m\x1b[?1006l\x1b[?1000l\x1b[?25h\x1b[?1049l")
+	if t.raw != nil {
+		disableRaw(t.In.Fd(), t.raw)
+		t.raw = nil
+	}
+}
+
+// Clear clears the back buffer, first resizing it if the TTY was resized.
+func (t *ANSITerminal) Clear() {
+	t.applyResize()
+	clear(t.back)
+}
+
+// Flush writes every cell which changed since the last Flush.
+func (t *ANSITerminal) Flush() error {
+	t.buf.Reset()
+	for i, g := range t.back {
+		if g == t.front[i] {
+			continue
+		}
+		t.front[i] = g
+
+		if pos := Vec(i%t.size.X, i/t.size.X); pos != t.cursor {
+			t.buf.WriteString("\x1b[")
+			t.buf.WriteString(strconv.Itoa(pos.Y + 1))
+			t.buf.WriteByte(';')
+			t.buf.WriteString(strconv.Itoa(pos.X + 1))
+			t.buf.WriteByte('H')
+			t.cursor = pos
+		}
+		t.writeStyle(g)
+		if g.Ch == 0 {
+			t.buf.WriteByte(' ')
+		} else {
+			t.buf.WriteRune(g.Ch)
+		}
+		t.cursor.X++
+	}
+	if t.buf.Len() == 0 {
+		return nil
+	}
+	_, err := t.Out.Write(t.buf.Bytes())
+	return err
+}
+
+// writeStyle writes the SGR sequence for a Glyph, unless already in effect.
+// Empty cells use the default terminal colors.
+func (t *ANSITerminal) writeStyle(g Glyph) {
+	var sgr string
+	switch {
+	case g.Ch == 0:
+		sgr = "\x1b[0m"
+	case t.TrueColor:
+		fr, fg, fb := g.Fg.RGB()
+		br, bg, bb := g.Bg.RGB()
+		sgr = "\x1b[38;2;" + rgb(fr, fg, fb) + ";48;2;" + rgb(br, bg, bb) + "m"
+	default:
+		sgr = "\x1b[38;5;" + strconv.Itoa(int(g.Fg)) + ";48;5;" + strconv.Itoa(int(g.Bg)) + "m"
+	}
+	if sgr != t.sgr {
+		t.buf.WriteString(sgr)
+		t.sgr = sgr
+	}
+}
+
+// rgb formats the three components of a 24-bit color separated by ';'.
+func rgb(r, g, b uint8) string {
+	return strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b))
+}
+
+// Data needed to decode escape sequences. These should be regarded as
+// constants.
+var (
+	csiFinalKeys = map[byte]Key{
+		'A': KeyArrowUp,
+		'B': KeyArrowDown,
+		'C': KeyArrowRight,
+		'D': KeyArrowLeft,
+		'H': KeyHome,
+		'F': KeyEnd,
+		'P': KeyF1,
+		'Q': KeyF2,
+		'R': KeyF3,
+		'S': KeyF4,
+	}
+	csiTildeKeys = map[int]Key{
+		1:  KeyHome,
+		2:  KeyInsert,
+		3:  KeyDelete,
+		4:  KeyEnd,
+		5:  KeyPgup,
+		6:  KeyPgdn,
+		7:  KeyHome,
+		8:  KeyEnd,
+		11: KeyF1,
+		12: KeyF2,
+		13: KeyF3,
+		14: KeyF4,
+		15: KeyF5,
+		17: KeyF6,
+		18: KeyF7,
+		19: KeyF8,
+		20: KeyF9,
+		21: KeyF10,
+		23: KeyF11,
+		24: KeyF12,
+	}
+	ss3Keys = map[byte]Key{
+		'A': KeyArrowUp,
+		'B': KeyArrowDown,
+		'C': KeyArrowRight,
+		'D': KeyArrowLeft,
+		'H': KeyHome,
+		'F': KeyEnd,
+		'P': KeyF1,
+		'Q': KeyF2,
+		'R': KeyF3,
+		'S': KeyF4,
+	}
+)
+
+// decodeInputs decodes a chunk of raw TTY input into Input. Unrecognized
+// escape sequences are discarded.
+func decodeInputs(b []byte) []Input {
+	var inputs []Input
+	for len(b) > 0 {
+		in, n, ok := decodeInput(b)
+		if ok {
+			inputs = append(inputs, in)
+		}
+		b = b[n:]
+	}
+	return inputs
+}
+
+// decodeInput decodes the first Input in a chunk of raw TTY input, returning
+// the Input, the number of bytes consumed, and whether the bytes formed an
+// Input.
+func decodeInput(b []byte) (Input, int, bool) {
+	if b[0] != byte(KeyEsc) {
+		r, n := utf8.DecodeRune(b)
+		if r == '\n' {
+			r = rune(KeyEnter)
+		}
+		in := KeyPress(Key(r))
+		in.Mod = controlMod(in.Key)
+		return in, n, true
+	}
+
+	// A lone escape is just the escape key.
+	if len(b) == 1 {
+		return KeyPress(KeyEsc), 1, true
+	}
+
+	switch b[1] {
+	case '[':
+		if in, n, ok := decodeCSI(b); n > 0 {
+			return in, n, ok
+		}
+	case 'O':
+		if len(b) >= 3 {
+			if k, ok := ss3Keys[b[2]]; ok {
+				return KeyPress(k), 3, true
+			}
+		}
+	}
+
+	// Otherwise escape followed by a Key means the Key was pressed with Alt.
+	in, n, ok := decodeInput(b[1:])
+	in.Mod |= ModAlt
+	return in, n + 1, ok
+}
+
+// decodeCSI decodes a control sequence of the form ESC [ params final. SGR
+// mouse reports of the form ESC [ < params M are also decoded. If the bytes
+// do not form a complete control sequence, decodeCSI consumes nothing.
+func decodeCSI(b []byte) (Input, int, bool) {
+	start, mouse := 2, false
+	if len(b) > 2 && b[2] == '<' {
+		start, mouse = 3, true
+	}
+
+	var params []int
+	param, hasParam := 0, false
+	for i := start; i < len(b); i++ {
+		switch c := b[i]; {
+		case '0' <= c && c <= '9':
+			param = param*10 + int(c-'0')
+			hasParam = true
+		case c == ';':
+			params = append(params, param)
+			param, hasParam = 0, false
+		case 0x40 <= c && c <= 0x7E:
+			if hasParam {
+				params = append(params, param)
+			}
+			if mouse {
+				in, ok := csiMouse(c, params)
+				return in, i + 1, ok
+			}
+			in, ok := csiKey(c, params)
+			return in, i + 1, ok
+		default:
+			return Input{}, 0, false
+		}
+	}
+	return Input{}, 0, false
+}
+
+// csiKey gets the Input for a control sequence with its final byte and
+// params.
+func csiKey(final byte, params []int) (Input, bool) {
+	var k Key
+	var ok bool
+	if final == '~' {
+		if len(params) == 0 {
+			return Input{}, false
+		}
+		k, ok = csiTildeKeys[params[0]]
+	} else {
+		k, ok = csiFinalKeys[final]
+	}
+
+	// The second param encodes modifiers as 1 + a bitmask of Shift (1), Alt
+	// (2) and Ctrl (4), which conveniently matches the bits of Mod.
+	in := KeyPress(k)
+	if len(params) >= 2 && params[1] > 1 {
+		in.Mod = Mod(params[1]-1) & (ModShift | ModAlt | ModCtrl)
+	}
+	return in, ok
+}
+
+// csiMouse gets the Input for an SGR mouse report with its final byte and
+// params. The final byte is 'M' for presses and 'm' for releases.
+func csiMouse(final byte, params []int) (Input, bool) {
+	if len(params) != 3 || (final != 'M' && final != 'm') {
+		return Input{}, false
+	}
+
+	// The low bits give the button, with bit 64 set for the wheel. Bits 4, 8
+	// and 16 give Shift, Alt and Ctrl. Positions are 1-based.
+	code := params[0]
+	in := Input{Kind: InputMouse, Pos: Vec(params[1]-1, params[2]-1)}
+	in.Mod = Mod(code>>2) & (ModShift | ModAlt | ModCtrl)
+	switch {
+	case final == 'm':
+		in.Mouse = MouseRelease
+	case code&64 != 0:
+		in.Mouse = MouseWheelUp + MouseButton(code&1)
+	default:
+		in.Mouse = MouseLeft + MouseButton(code&3)
+		if code&3 == 3 {
+			in.Mouse = MouseRelease
+		}
+	}
+	return in, true
+}
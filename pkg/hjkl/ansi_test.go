@@ -0,0 +1,119 @@
+package hjkl
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeInputs(t *testing.T) {
+	alt := func(in Input) Input {
+		in.Mod |= ModAlt
+		return in
+	}
+	cases := []struct {
+		In   string
+		Want []Input
+	}{
+		{"hjkl", []Input{KeyPress('h'), KeyPress('j'), KeyPress('k'), KeyPress('l')}},
+		{"\x1b", []Input{KeyPress(KeyEsc)}},
+		{"\r\x03", []Input{KeyPress(KeyEnter), {Kind: InputKey, Key: KeyCtrlC, Mod: ModCtrl}}},
+		{"é", []Input{KeyPress('é')}},
+		{"\x1b[A\x1b[B\x1b[C\x1b[D", []Input{
+			KeyPress(KeyArrowUp),
+			KeyPress(KeyArrowDown),
+			KeyPress(KeyArrowRight),
+			KeyPress(KeyArrowLeft),
+		}},
+		{"\x1bOA\x1bOP", []Input{KeyPress(KeyArrowUp), KeyPress(KeyF1)}},
+		{"\x1b[5~\x1b[6~\x1b[3~", []Input{KeyPress(KeyPgup), KeyPress(KeyPgdn), KeyPress(KeyDelete)}},
+		{"\x1b[15~\x1b[24~", []Input{KeyPress(KeyF5), KeyPress(KeyF12)}},
+		{"\x1b[1;3A", []Input{alt(KeyPress(KeyArrowUp))}},
+		{"\x1b[1;6B", []Input{{Kind: InputKey, Key: KeyArrowDown, Mod: ModShift | ModCtrl}}},
+		{"\x1bh", []Input{alt(KeyPress('h'))}},
+		{"\x1b\x1b", []Input{alt(KeyPress(KeyEsc))}},
+		{"\x1b[99~x", []Input{KeyPress('x')}},
+		{"\x1b[", []Input{alt(KeyPress('['))}},
+		{"\x1b[<0;5;3M", []Input{{Kind: InputMouse, Mouse: MouseLeft, Pos: Vec(4, 2)}}},
+		{"\x1b[<2;1;1M", []Input{{Kind: InputMouse, Mouse: MouseRight, Pos: Vec(0, 0)}}},
+		{"\x1b[<0;5;3m", []Input{{Kind: InputMouse, Mouse: MouseRelease, Pos: Vec(4, 2)}}},
+		{"\x1b[<65;2;2M", []Input{{Kind: InputMouse, Mouse: MouseWheelDown, Pos: Vec(1, 1)}}},
+		{"\x1b[<8;2;2M", []Input{{Kind: InputMouse, Mouse: MouseLeft, Mod: ModAlt, Pos: Vec(1, 1)}}},
+	}
+	for _, c := range cases {
+		if got := decodeInputs([]byte(c.In)); !reflect.DeepEqual(got, c.Want) {
+			t.Errorf("decodeInputs(%q) = %v, want %v", c.In, got, c.Want)
+		}
+	}
+}
+
+func TestANSITerminal_Flush(t *testing.T) {
+	var out bytes.Buffer
+	term := &ANSITerminal{Out: &out}
+	term.resize(Vec(3, 2))
+
+	term.Blit(Vec(0, 0), ChFg('@', ColorRed))
+	term.Blit(Vec(1, 0), ChFg('#', ColorRed))
+	term.Blit(Vec(5, 5), Ch('X'))
+	if err := term.Flush(); err != nil {
+		t.Fatal("ANSITerminal.Flush gave error", err)
+	}
+	want := "\x1b[1;1H\x1b[38;5;1;48;5;0m@#\x1b[0m \x1b[2;1H   "
+	if got := out.String(); got != want {
+		t.Errorf("ANSITerminal.Flush wrote %q, want %q", got, want)
+	}
+
+	// Only changed cells should be rewritten.
+	out.Reset()
+	term.Clear()
+	term.Blit(Vec(0, 0), ChFg('@', ColorRed))
+	term.Blit(Vec(2, 1), ChFg('D', ColorRed))
+	if err := term.Flush(); err != nil {
+		t.Fatal("ANSITerminal.Flush gave error", err)
+	}
+	want = "\x1b[1;2H \x1b[2;3H\x1b[38;5;1;48;5;0mD"
+	if got := out.String(); got != want {
+		t.Errorf("ANSITerminal.Flush wrote %q, want %q", got, want)
+	}
+
+	// Nothing changed, so nothing should be written.
+	out.Reset()
+	if err := term.Flush(); err != nil || out.Len() != 0 {
+		t.Errorf("ANSITerminal.Flush wrote %q without changes", out.String())
+	}
+}
+
+func TestANSITerminal_TrueColor(t *testing.T) {
+	var out bytes.Buffer
+	term := &ANSITerminal{Out: &out, TrueColor: true}
+	term.resize(Vec(1, 1))
+	term.Blit(Vec(0, 0), Glyph{Ch: '@', Fg: 196, Bg: 244})
+	if err := term.Flush(); err != nil {
+		t.Fatal("ANSITerminal.Flush gave error", err)
+	}
+	want := "\x1b[1;1H\x1b[38;2;255;0;0;48;2;128;128;128m@"
+	if got := out.String(); got != want {
+		t.Errorf("ANSITerminal.Flush wrote %q, want %q", got, want)
+	}
+}
+
+func TestColor_RGB(t *testing.T) {
+	cases := []struct {
+		Color   Color
+		R, G, B uint8
+	}{
+		{ColorBlack, 0, 0, 0},
+		{ColorLightWhite, 255, 255, 255},
+		{ColorRed, 128, 0, 0},
+		{16, 0, 0, 0},
+		{196, 255, 0, 0},
+		{231, 255, 255, 255},
+		{232, 8, 8, 8},
+		{255, 238, 238, 238},
+	}
+	for _, c := range cases {
+		if r, g, b := c.Color.RGB(); r != c.R || g != c.G || b != c.B {
+			t.Errorf("Color(%d).RGB() = %d, %d, %d", c.Color, r, g, b)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package btree
+
+// Blackboard holds the state a behavior tree runs against, such as the actor
+// running the tree, or a target chosen by one node for use by the next. The
+// zero value is an empty Blackboard ready to use.
+type Blackboard struct {
+	entries map[any]any
+}
+
+// Clear removes every entry from the Blackboard.
+func (b *Blackboard) Clear() {
+	clear(b.entries)
+}
+
+// Key identifies an entry of type T on a Blackboard. Each Key is distinct, even
+// from another Key with the same Name.
+type Key[T any] struct {
+	Name string
+}
+
+// NewKey creates a new Key with the given name.
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name}
+}
+
+// String gets the name of the Key.
+func (k *Key[T]) String() string {
+	return k.Name
+}
+
+// Get gets the entry for the Key, and whether it was set.
+func (k *Key[T]) Get(b *Blackboard) (T, bool) {
+	e, ok := b.entries[k]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	// A nil entry for an interface type fails the assertion, but is still set.
+	v, _ := e.(T)
+	return v, true
+}
+
+// Set sets the entry for the Key.
+func (k *Key[T]) Set(b *Blackboard, v T) {
+	if b.entries == nil {
+		b.entries = make(map[any]any)
+	}
+	b.entries[k] = v
+}
+
+// Delete removes the entry for the Key.
+func (k *Key[T]) Delete(b *Blackboard) {
+	delete(b.entries, k)
+}
+
+// Tree is a behavior tree whose leaves read and write a Blackboard. The tree is
+// built once, and then may be run against the Blackboard of as many actors as
+// needed, since the leaves get their state from the Blackboard rather than
+// capturing it.
+//
+// Since the nodes of the tree are shared, Run resets the tree before running
+// it, so a Tree decides afresh on every Run rather than resuming a Running
+// node. For the same reason, a Tree is not reentrant, so no leaf may Run the
+// Tree it belongs to, and a Tree is not safe for concurrent use, so each
+// goroutine needs a Tree of its own.
+type Tree struct {
+	root  Behavior
+	board *Blackboard
+}
+
+// NewTree creates a Tree with the Behavior from build. Any leaves should be
+// created with the given Tree, so that they run against its Blackboard.
+func NewTree(build func(*Tree) Behavior) *Tree {
+	t := &Tree{}
+	t.root = build(t)
+	return t
+}
+
+// Run resets and runs the Tree against the given Blackboard. Run panics if the
+// Tree is already running.
+func (t *Tree) Run(b *Blackboard) State {
+	if t.board != nil {
+		panic("btree: Tree.Run called while the Tree is running")
+	}
+	t.board = b
+	defer func() { t.board = nil }()
+	t.root.Reset()
+	return t.root.Run()
+}
+
+// Action gets a leaf which calls the function with the Blackboard and returns
+// the result.
+func (t *Tree) Action(f func(*Blackboard) State) Behavior {
+	return Action(func() State {
+		return f(t.board)
+	})
+}
+
+// Func gets a leaf which calls the function with the Blackboard and succeeds.
+func (t *Tree) Func(f func(*Blackboard)) Behavior {
+	return Func(func() {
+		f(t.board)
+	})
+}
+
+// Conditional gets a leaf which calls the function with the Blackboard, and
+// succeeds on true, or fails otherwise.
+func (t *Tree) Conditional(f func(*Blackboard) bool) Behavior {
+	return Conditional(func() bool {
+		return f(t.board)
+	})
+}
+
+// Has gets a leaf which succeeds if the Key is set.
+func Has[T any](t *Tree, k *Key[T]) Behavior {
+	return t.Conditional(func(b *Blackboard) bool {
+		_, ok := k.Get(b)
+		return ok
+	})
+}
+
+// Check gets a leaf which succeeds if the Key is set and its entry satisfies
+// the predicate.
+func Check[T any](t *Tree, k *Key[T], pred func(T) bool) Behavior {
+	return t.Conditional(func(b *Blackboard) bool {
+		v, ok := k.Get(b)
+		return ok && pred(v)
+	})
+}
+
+// Set gets a leaf which sets the Key to the given value and succeeds.
+func Set[T any](t *Tree, k *Key[T], v T) Behavior {
+	return t.Func(func(b *Blackboard) {
+		k.Set(b, v)
+	})
+}
+
+// Unset gets a leaf which removes the entry for the Key and succeeds.
+func Unset[T any](t *Tree, k *Key[T]) Behavior {
+	return t.Func(func(b *Blackboard) {
+		k.Delete(b)
+	})
+}
+
+// With gets a leaf which calls the function with the entry for the Key, and
+// returns the result. The leaf fails without calling the function if the Key
+// is not set.
+func With[T any](t *Tree, k *Key[T], f func(*Blackboard, T) State) Behavior {
+	return t.Action(func(b *Blackboard) State {
+		v, ok := k.Get(b)
+		if !ok {
+			return Failure
+		}
+		return f(b, v)
+	})
+}
@@ -0,0 +1,116 @@
+package btree
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	var b Blackboard
+	name, other := NewKey[string]("name"), NewKey[string]("name")
+	count := NewKey[int]("count")
+
+	if _, ok := name.Get(&b); ok {
+		t.Error("Key.Get gave entry on empty Blackboard")
+	}
+	name.Set(&b, "foo")
+	count.Set(&b, 3)
+	if got, ok := name.Get(&b); !ok || got != "foo" {
+		t.Errorf("Key.Get gave %q instead of %q", got, "foo")
+	}
+	if got, ok := count.Get(&b); !ok || got != 3 {
+		t.Errorf("Key.Get gave %d instead of 3", got)
+	}
+	if _, ok := other.Get(&b); ok {
+		t.Error("Key.Get gave entry for a different Key with the same name")
+	}
+
+	name.Delete(&b)
+	if _, ok := name.Get(&b); ok {
+		t.Error("Key.Delete failed to remove entry")
+	}
+	b.Clear()
+	if _, ok := count.Get(&b); ok {
+		t.Error("Blackboard.Clear failed to remove entry")
+	}
+
+	err := NewKey[error]("err")
+	err.Set(&b, nil)
+	if _, ok := err.Get(&b); !ok {
+		t.Error("Key.Get ignored nil entry")
+	}
+}
+
+func TestTree(t *testing.T) {
+	hp, target := NewKey[int]("hp"), NewKey[string]("target")
+	attacks := 0
+	tree := NewTree(func(t *Tree) Behavior {
+		return Selection(
+			Sequence(
+				Check(t, hp, func(hp int) bool { return hp < 5 }),
+				Unset(t, target),
+			),
+			Sequence(
+				Invert(Has(t, target)),
+				Set(t, target, "hero"),
+			),
+			With(t, target, func(b *Blackboard, target string) State {
+				attacks++
+				return Success
+			}),
+		)
+	})
+
+	// The same Tree runs against separate Blackboard without interference.
+	var a, b Blackboard
+	hp.Set(&a, 10)
+	hp.Set(&b, 1)
+	target.Set(&b, "hero")
+	for range 2 {
+		if got := tree.Run(&a); got != Success {
+			t.Errorf("Tree.Run gave %v instead of Success", got)
+		}
+		if got := tree.Run(&b); got != Success {
+			t.Errorf("Tree.Run gave %v instead of Success", got)
+		}
+	}
+
+	if got, ok := target.Get(&a); !ok || got != "hero" {
+		t.Error("Tree failed to set target")
+	}
+	if _, ok := target.Get(&b); ok {
+		t.Error("Tree failed to unset target")
+	}
+	if attacks != 1 {
+		t.Errorf("Tree attacked %d times instead of 1", attacks)
+	}
+}
+
+func TestWith_Unset(t *testing.T) {
+	k := NewKey[int]("k")
+	called := false
+	tree := NewTree(func(t *Tree) Behavior {
+		return With(t, k, func(*Blackboard, int) State {
+			called = true
+			return Success
+		})
+	})
+	if got := tree.Run(&Blackboard{}); got != Failure {
+		t.Errorf("With gave %v instead of Failure", got)
+	}
+	if called {
+		t.Error("With called function without the Key set")
+	}
+}
+
+func TestTree_Reentrant(t *testing.T) {
+	var tree *Tree
+	tree = NewTree(func(t *Tree) Behavior {
+		return t.Func(func(b *Blackboard) {
+			tree.Run(b)
+		})
+	})
+	defer func() {
+		if recover() == nil {
+			t.Error("Tree.Run failed to panic when reentered")
+		}
+	}()
+	tree.Run(&Blackboard{})
+}
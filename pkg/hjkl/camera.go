@@ -0,0 +1,89 @@
+package hjkl
+
+// CameraWidget is a TilesWidget which scrolls to follow a target Mob, so the
+// collection of Tile may be far larger than the Window.
+//
+// The camera keeps a focus Tile offset which it draws at the center of the
+// Window. Each Draw the focus follows the Target, but only once the Target
+// leaves the DeadZone, which extends that many cells from the focus in each
+// direction. A zero DeadZone keeps the Target centered. Regardless of the
+// focus, the camera never scrolls past the edges of the Tile, and collections
+// of Tile smaller than the Window are drawn from the top-left corner.
+type CameraWidget struct {
+	TilesWidget
+	Target   *Mob
+	DeadZone Vector
+	Focus    Vector
+}
+
+// NewLevelCamera creates a CameraWidget which draws the given Level, initially
+// focused on the target Mob.
+func NewLevelCamera(pos, size Vector, level *Level, target *Mob) *CameraWidget {
+	w := NewCameraWidget(pos, size, level.Tiles, target)
+	w.Level = level
+	return w
+}
+
+// NewCameraWidget creates a CameraWidget with the given collection of Tile,
+// initially focused on the target Mob.
+func NewCameraWidget(pos, size Vector, tiles []*Tile, target *Mob) *CameraWidget {
+	w := &CameraWidget{TilesWidget: *NewTilesWidget(pos, size, tiles), Target: target}
+	if target != nil && target.Pos != nil {
+		w.Focus = target.Pos.Offset
+	}
+	return w
+}
+
+// Draw moves the focus to follow the Target, then draws the visible Tile.
+func (w *CameraWidget) Draw(c Canvas) {
+	w.Follow()
+	w.drawFrom(c, w.Origin())
+}
+
+// Follow moves the focus just enough to put the Target in the DeadZone. If
+// the Target has no position, the focus is left unchanged.
+func (w *CameraWidget) Follow() {
+	if w.Target == nil || w.Target.Pos == nil {
+		return
+	}
+	pos := w.Target.Pos.Offset
+	w.Focus.X = max(pos.X-w.DeadZone.X, min(pos.X+w.DeadZone.X, w.Focus.X))
+	w.Focus.Y = max(pos.Y-w.DeadZone.Y, min(pos.Y+w.DeadZone.Y, w.Focus.Y))
+}
+
+// Origin gets the Tile offset drawn at the top-left corner of the Window.
+func (w *CameraWidget) Origin() Vector {
+	var lo, hi Vector
+	switch {
+	case w.Level != nil:
+		hi = w.Level.Size.Sub(Vec(1, 1))
+	case len(w.Tiles) == 0:
+		return Vector{}
+	default:
+		lo, hi = w.Tiles[0].Offset, w.Tiles[0].Offset
+		for _, t := range w.Tiles[1:] {
+			lo = Vec(min(lo.X, t.Offset.X), min(lo.Y, t.Offset.Y))
+			hi = Vec(max(hi.X, t.Offset.X), max(hi.Y, t.Offset.Y))
+		}
+	}
+	return Vec(
+		cameraAxis(w.Focus.X-w.Size.X/2, lo.X, hi.X, w.Size.X),
+		cameraAxis(w.Focus.Y-w.Size.Y/2, lo.Y, hi.Y, w.Size.Y),
+	)
+}
+
+// cameraAxis clamps the origin along a single axis so that a view of the given
+// size stays within the bounds lo and hi (inclusive).
+func cameraAxis(origin, lo, hi, size int) int {
+	return max(lo, min(hi+1-size, origin))
+}
+
+// ToScreen converts a Tile offset into a position on the Canvas.
+func (w *CameraWidget) ToScreen(offset Vector) Vector {
+	return offset.Sub(w.Origin()).Add(w.Pos)
+}
+
+// ToMap converts a position on the Canvas into a Tile offset.
+func (w *CameraWidget) ToMap(pos Vector) Vector {
+	return pos.Sub(w.Pos).Add(w.Origin())
+}
@@ -0,0 +1,93 @@
+package hjkl
+
+import "testing"
+
+// NewTestGrid creates a grid of connected Tile with the given size. Tile on
+// the edges have face '#' while the rest have face '.'.
+func NewTestGrid(cols, rows int) []*Tile {
+	level := NewLevel(cols, rows, func(v Vector) *Tile {
+		t := NewTile(v)
+		if v.X == 0 || v.Y == 0 || v.X == cols-1 || v.Y == rows-1 {
+			t.Face = Ch('#')
+		}
+		return t
+	})
+	return level.Tiles
+}
+
+func TestCameraWidget(t *testing.T) {
+	tiles := NewTestGrid(20, 10)
+	hero := NewMob(Ch('@'))
+	PlaceMob(hero, tiles[5*20+10])
+	w := NewCameraWidget(Vec(1, 1), Vec(5, 3), tiles, hero)
+
+	cases := []struct {
+		Pos    Vector
+		Origin Vector
+	}{
+		{Vec(10, 5), Vec(8, 4)},
+		{Vec(12, 4), Vec(10, 3)},
+		{Vec(1, 1), Vec(0, 0)},
+		{Vec(19, 9), Vec(15, 7)},
+	}
+	for _, c := range cases {
+		PlaceMob(hero, tiles[c.Pos.Y*20+c.Pos.X])
+		canvas := make(MockCanvas)
+		w.Draw(canvas)
+		if origin := w.Origin(); origin != c.Origin {
+			t.Errorf("CameraWidget at %v gave origin %v", c.Pos, origin)
+		}
+		if len(canvas) != 15 {
+			t.Errorf("CameraWidget at %v drew %d cells", c.Pos, len(canvas))
+		}
+		if screen := w.ToScreen(c.Pos); canvas[screen] != Ch('@') {
+			t.Errorf("CameraWidget at %v drew %v at %v", c.Pos, canvas[screen], screen)
+		}
+		if w.ToMap(w.ToScreen(c.Pos)) != c.Pos {
+			t.Error("CameraWidget.ToMap did not invert ToScreen")
+		}
+	}
+}
+
+func TestCameraWidget_DeadZone(t *testing.T) {
+	tiles := NewTestGrid(40, 40)
+	hero := NewMob(Ch('@'))
+	PlaceMob(hero, tiles[20*40+20])
+	w := NewCameraWidget(Vector{}, Vec(11, 11), tiles, hero)
+	w.DeadZone = Vec(2, 2)
+
+	cases := []struct {
+		Pos   Vector
+		Focus Vector
+	}{
+		{Vec(22, 18), Vec(20, 20)},
+		{Vec(23, 18), Vec(21, 20)},
+		{Vec(23, 15), Vec(21, 17)},
+		{Vec(19, 17), Vec(21, 17)},
+		{Vec(18, 17), Vec(20, 17)},
+	}
+	for _, c := range cases {
+		PlaceMob(hero, tiles[c.Pos.Y*40+c.Pos.X])
+		w.Draw(make(MockCanvas))
+		if w.Focus != c.Focus {
+			t.Errorf("CameraWidget at %v gave focus %v", c.Pos, w.Focus)
+		}
+	}
+}
+
+func TestCameraWidget_Small(t *testing.T) {
+	tiles := NewTestGrid(3, 3)
+	hero := NewMob(Ch('@'))
+	PlaceMob(hero, tiles[4])
+	canvas := make(MockCanvas)
+	NewCameraWidget(Vec(2, 1), Vec(8, 5), tiles, hero).Draw(canvas)
+	expected := []string{
+		"        ",
+		"  ###   ",
+		"  #@#   ",
+		"  ###   ",
+	}
+	if !canvas.Equals(expected) {
+		t.Error("CameraWidget.Draw produced incorrect buffer", canvas)
+	}
+}
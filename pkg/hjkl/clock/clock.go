@@ -1,11 +1,17 @@
 // Package clock contains an implementation of a delta clock.
 package clock
 
-// node stores events for a particular delta in a Clock.
+import (
+	"iter"
+	"slices"
+)
+
+// node stores events for a particular delta in a Clock, in the order they
+// were scheduled.
 type node[T comparable] struct {
 	delta  int
 	link   *node[T]
-	events map[T]struct{}
+	events []T
 }
 
 // Clock implements a delta clock data structure, which is a linked list in
@@ -14,14 +20,28 @@ type node[T comparable] struct {
 // an be done in O(1) time by decrementing or removing the head node delta.
 // Adding new events can be done in O(n) time, where n is the number of nodes
 // (not the number of events).
+//
+// Events with the same delta are popped in the order they were scheduled, so
+// a Clock behaves the same way every run.
 type Clock[T comparable] struct {
 	head  *node[T]
 	nodes map[T]*node[T]
+	now   int
 }
 
 // New creates an empty Clock.
 func New[T comparable]() *Clock[T] {
-	return &Clock[T]{nil, make(map[T]*node[T])}
+	return &Clock[T]{nodes: make(map[T]*node[T])}
+}
+
+// Now gets the total time the Clock has advanced since it was created.
+func (c *Clock[T]) Now() int {
+	return c.now
+}
+
+// Len gets the number of scheduled events.
+func (c *Clock[T]) Len() int {
+	return len(c.nodes)
 }
 
 // Schedule adds an event to the queue at the given delta.
@@ -42,7 +62,7 @@ func (c *Clock[T]) Schedule(t T, delta int) {
 		curr = next
 	} else {
 		// Desired node didn't exist, so create it with link to next node.
-		curr = &node[T]{delta, next, make(map[T]struct{})}
+		curr = &node[T]{delta, next, nil}
 
 		if prev == nil {
 			// If prev == nil, we're at the beginning of the list.
@@ -60,18 +80,40 @@ func (c *Clock[T]) Schedule(t T, delta int) {
 	}
 
 	// Add the event to the curr node.
-	curr.events[t] = struct{}{}
+	curr.events = append(curr.events, t)
 	c.nodes[t] = curr
 }
 
 // Unschedule removes an event from the queue.
 func (c *Clock[T]) Unschedule(t T) {
 	if n, ok := c.nodes[t]; ok {
-		delete(n.events, t)
+		i := slices.Index(n.events, t)
+		n.events = slices.Delete(n.events, i, i+1)
 		delete(c.nodes, t)
 	}
 }
 
+// Contains determines whether an event is scheduled.
+func (c *Clock[T]) Contains(t T) bool {
+	_, ok := c.nodes[t]
+	return ok
+}
+
+// Delta gets the remaining delta before an event is popped by Tick. If the
+// event is not scheduled, Delta returns false.
+func (c *Clock[T]) Delta(t T) (int, bool) {
+	n, ok := c.nodes[t]
+	if !ok {
+		return 0, false
+	}
+
+	delta := 0
+	for curr := c.head; curr != n; curr = curr.link {
+		delta += curr.delta
+	}
+	return delta + n.delta, true
+}
+
 // Tick advances the clock by one and pops any events with non-positive delta.
 func (c *Clock[T]) Tick() []T {
 	// Nothing to do if there aren't any scheduled events.
@@ -81,13 +123,12 @@ func (c *Clock[T]) Tick() []T {
 
 	// Since all deltas are relative, this decrements the entire clock.
 	c.head.delta -= 1
+	c.now++
 
 	// Pop events from all nodes with non-positive delta.
 	var events []T
 	for c.head != nil && c.head.delta <= 0 {
-		for t := range c.head.events {
-			events = append(events, t)
-		}
+		events = append(events, c.head.events...)
 		c.head = c.head.link
 	}
 
@@ -98,3 +139,59 @@ func (c *Clock[T]) Tick() []T {
 
 	return events
 }
+
+// Advance advances the clock directly to the next scheduled events, and pops
+// them. Advance also returns the time skipped. Events scheduled with zero
+// delta are already due, so Advance pops them without skipping any time. If
+// nothing is scheduled, Advance does nothing and returns zero.
+func (c *Clock[T]) Advance() ([]T, int) {
+	if len(c.nodes) == 0 {
+		return nil, 0
+	}
+
+	// Discard nodes emptied by Unschedule, keeping their deltas.
+	skipped := 0
+	for len(c.head.events) == 0 {
+		skipped += c.head.delta
+		c.head = c.head.link
+	}
+	skipped = max(0, skipped+c.head.delta)
+	c.now += skipped
+
+	// Since the remaining deltas are relative to the head, popping the head
+	// is all it takes to advance the entire clock.
+	events := c.head.events
+	c.head = c.head.link
+	for _, t := range events {
+		delete(c.nodes, t)
+	}
+	return events, skipped
+}
+
+// Peek gets the next event which will be popped, without advancing the
+// clock. If nothing is scheduled, Peek returns false.
+func (c *Clock[T]) Peek() (T, bool) {
+	for curr := c.head; curr != nil; curr = curr.link {
+		if len(curr.events) > 0 {
+			return curr.events[0], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// All iterates over the scheduled events and their deltas, in the order they
+// will be popped.
+func (c *Clock[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		delta := 0
+		for curr := c.head; curr != nil; curr = curr.link {
+			delta += curr.delta
+			for _, t := range curr.events {
+				if !yield(t, delta) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -67,3 +67,115 @@ func TestClock_Unschedule(t *testing.T) {
 		t.Error("Clock.Unschedule failed to unschedule")
 	}
 }
+
+func TestClock_Contains(t *testing.T) {
+	c := New[string]()
+	c.Schedule("a", 1)
+	c.Schedule("b", 2)
+	c.Unschedule("b")
+	if !c.Contains("a") {
+		t.Error("Clock.Contains missed scheduled event")
+	}
+	if c.Contains("b") {
+		t.Error("Clock.Contains found unscheduled event")
+	}
+	c.Tick()
+	if c.Contains("a") {
+		t.Error("Clock.Contains found popped event")
+	}
+}
+
+func TestClock_Delta(t *testing.T) {
+	c := New[string]()
+	c.Schedule("a", 3)
+	c.Schedule("b", 5)
+	c.Schedule("c", 3)
+	c.Tick()
+	cases := map[string]int{"a": 2, "b": 4, "c": 2}
+	for s, want := range cases {
+		if got, ok := c.Delta(s); !ok || got != want {
+			t.Errorf("Clock.Delta(%s) = %d", s, got)
+		}
+	}
+	if _, ok := c.Delta("d"); ok {
+		t.Error("Clock.Delta found unscheduled event")
+	}
+}
+
+func TestClock_Order(t *testing.T) {
+	c := New[string]()
+	for _, s := range []string{"d", "b", "e", "a", "c"} {
+		c.Schedule(s, 2)
+	}
+	c.Unschedule("e")
+	c.Schedule("b", 2)
+
+	c.Tick()
+	want := []string{"d", "a", "c", "b"}
+	if got := c.Tick(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Clock.Tick gave %v instead of %v", got, want)
+	}
+}
+
+func TestClock_Advance(t *testing.T) {
+	c := New[string]()
+	if got, skipped := c.Advance(); got != nil || skipped != 0 {
+		t.Error("Clock.Advance advanced an empty clock")
+	}
+
+	c.Schedule("a", 3)
+	c.Schedule("b", 7)
+	c.Schedule("c", 7)
+	c.Schedule("x", 1)
+	c.Unschedule("x")
+	c.Tick()
+
+	if e, ok := c.Peek(); !ok || e != "a" {
+		t.Errorf("Clock.Peek gave %q", e)
+	}
+	if got, skipped := c.Advance(); !reflect.DeepEqual(got, []string{"a"}) || skipped != 2 {
+		t.Errorf("Clock.Advance gave %v after %d", got, skipped)
+	}
+	if c.Now() != 3 || c.Len() != 2 {
+		t.Errorf("Clock.Advance left Now %d and Len %d", c.Now(), c.Len())
+	}
+
+	c.Schedule("d", 0)
+	if got, skipped := c.Advance(); !reflect.DeepEqual(got, []string{"d"}) || skipped != 0 {
+		t.Errorf("Clock.Advance gave %v after %d for due event", got, skipped)
+	}
+	if got, skipped := c.Advance(); !reflect.DeepEqual(got, []string{"b", "c"}) || skipped != 4 {
+		t.Errorf("Clock.Advance gave %v after %d", got, skipped)
+	}
+	if c.Now() != 7 || c.Len() != 0 {
+		t.Errorf("Clock.Advance left Now %d and Len %d", c.Now(), c.Len())
+	}
+	if _, ok := c.Peek(); ok {
+		t.Error("Clock.Peek found event in empty clock")
+	}
+}
+
+func TestClock_All(t *testing.T) {
+	c := New[string]()
+	c.Schedule("a", 4)
+	c.Schedule("b", 2)
+	c.Schedule("c", 4)
+	c.Schedule("d", 9)
+	c.Unschedule("d")
+
+	var events []string
+	var deltas []int
+	for e, delta := range c.All() {
+		events = append(events, e)
+		deltas = append(deltas, delta)
+		if got, _ := c.Delta(e); got != delta {
+			t.Errorf("Clock.All gave delta %d for %s instead of %d", delta, e, got)
+		}
+	}
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("Clock.All gave %v instead of %v", events, want)
+	}
+	if want := []int{2, 4, 4}; !reflect.DeepEqual(deltas, want) {
+		t.Errorf("Clock.All gave deltas %v instead of %v", deltas, want)
+	}
+}
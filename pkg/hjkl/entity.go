@@ -1,5 +1,7 @@
 package hjkl
 
+import "iter"
+
 // Event is a message handled by Entity.
 type Event any
 
@@ -29,6 +31,18 @@ func (s *ComponentSlice[E]) Add(c Component[E]) {
 	*s = append(*s, c)
 }
 
+// Remove removes the first occurrence of a Component from the ComponentSlice.
+// The Component must be comparable, such as a pointer, since a ComponentFunc
+// cannot be found again once added.
+func (s *ComponentSlice[E]) Remove(c Component[E]) {
+	for i, x := range *s {
+		if x == c {
+			*s = append((*s)[:i], (*s)[i+1:]...)
+			return
+		}
+	}
+}
+
 // Handle has each constiutent Component handle the Event.
 func (s ComponentSlice[E]) Handle(e E, v Event) {
 	for _, c := range s {
@@ -81,9 +95,12 @@ type SetOccupant struct {
 	Value *Mob
 }
 
-// Move is an Event which triggers movement.
+// Move is an Event which triggers movement. Once handled, Cost holds the time
+// spent by the Mob: CostMove for a step, CostAttack for a Bump, or zero if the
+// Mob went nowhere.
 type Move struct {
 	Delta Vector
+	Cost  int
 }
 
 // Bump is an Event sent upon bumping a Mob.
@@ -117,23 +134,35 @@ func (e *Mob) Handle(v Event) {
 	case *SetPos:
 		e.Pos = v.Value
 	case *Move:
-		if dst, ok := e.Pos.Adjacent[v.Delta]; ok {
+		if dst, ok := e.Pos.Neighbor(v.Delta); ok {
 			if dst.Occupant != nil {
+				v.Cost = CostAttack
 				e.Handle(&Bump{dst.Occupant})
 			} else if !dst.Pass {
 				e.Handle(&Collide{dst})
 			} else {
+				v.Cost = CostMove
 				e.Pos.Handle(&SetOccupant{nil})
 				dst.Handle(&SetOccupant{e})
 				e.Handle(&SetPos{dst})
 			}
 		}
+	case *Remove:
+		if e.Pos != nil {
+			e.Pos.Handle(&SetOccupant{nil})
+			e.Handle(&SetPos{nil})
+		}
 	}
 
 	e.Components.Handle(e, v)
 }
 
 // Tile represents a single square in the game mpa.
+//
+// The neighbors of a Tile are given by the explicit links in Adjacent, along
+// with the compass neighbors in the Level containing the Tile, if any. Links in
+// Adjacent take precedence, so a Level Tile may still link elsewhere, such as
+// to stairs. Adjacent is nil until the first Link.
 type Tile struct {
 	Offset   Vector
 	Face     Glyph
@@ -142,16 +171,66 @@ type Tile struct {
 	Adjacent map[Vector]*Tile
 
 	Components ComponentSlice[*Tile]
+
+	level *Level
 }
 
 // NewTile constructs a new Tile with the given Vector offset.
 func NewTile(offset Vector) *Tile {
 	return &Tile{
-		Offset:   offset,
-		Face:     Ch('.'),
-		Pass:     true,
-		Adjacent: make(map[Vector]*Tile),
+		Offset: offset,
+		Face:   Ch('.'),
+		Pass:   true,
+	}
+}
+
+// Link adds an explicit link from the Tile to another in the given direction.
+func (e *Tile) Link(dir Vector, dst *Tile) {
+	if e.Adjacent == nil {
+		e.Adjacent = make(map[Vector]*Tile)
+	}
+	e.Adjacent[dir] = dst
+}
+
+// Neighbor gets the Tile in the given direction, if there is one.
+func (e *Tile) Neighbor(dir Vector) (*Tile, bool) {
+	if dst, ok := e.Adjacent[dir]; ok {
+		return dst, true
 	}
+	if e.level != nil && compass(dir) {
+		if dst := e.level.At(e.Offset.Add(dir)); dst != nil {
+			return dst, true
+		}
+	}
+	return nil, false
+}
+
+// Neighbors iterates over each direction and neighboring Tile. The compass
+// neighbors come first, in the order of CompassDirs, followed by any other
+// explicit links in Adjacent.
+func (e *Tile) Neighbors() iter.Seq2[Vector, *Tile] {
+	return func(yield func(Vector, *Tile) bool) {
+		for _, dir := range CompassDirs {
+			if dst, ok := e.Neighbor(dir); ok && !yield(dir, dst) {
+				return
+			}
+		}
+		for dir, dst := range e.Adjacent {
+			if !compass(dir) && !yield(dir, dst) {
+				return
+			}
+		}
+	}
+}
+
+// Level gets the Level containing the Tile, or nil if there is none.
+func (e *Tile) Level() *Level {
+	return e.level
+}
+
+// compass returns true if the Vector is one of the CompassDirs.
+func compass(dir Vector) bool {
+	return dir != Vector{} && -1 <= dir.X && dir.X <= 1 && -1 <= dir.Y && dir.Y <= 1
 }
 
 // Handle implements Entity for Tile.
@@ -34,6 +34,30 @@ func TestGet(t *testing.T) {
 	}
 }
 
+type NopComponent struct {
+	ID int
+}
+
+func (*NopComponent) Handle(*Mob, Event) {}
+
+func TestComponentSlice_Remove(t *testing.T) {
+	a, b := &NopComponent{1}, &NopComponent{2}
+	var s ComponentSlice[*Mob]
+	s.Add(a)
+	s.Add(ComponentFunc[*Mob](func(*Mob, Event) {}))
+	s.Add(b)
+	s.Add(a)
+
+	s.Remove(a)
+	if len(s) != 3 || s[1] != b || s[2] != a {
+		t.Error("ComponentSlice.Remove removed incorrect Component")
+	}
+	s.Remove(&NopComponent{3})
+	if len(s) != 3 {
+		t.Error("ComponentSlice.Remove removed missing Component")
+	}
+}
+
 func TestMob_Face(t *testing.T) {
 	m := NewMob(Ch('@'))
 	if got := Get(m, &Face{}); got != Ch('@') {
@@ -60,7 +84,7 @@ func TestMob_Bump(t *testing.T) {
 	n := NewMob(Ch('D'))
 	a := NewTile(Vec(1, 1))
 	b := NewTile(Vec(2, 1))
-	a.Adjacent[Vec(1, 0)] = b
+	a.Link(Vec(1, 0), b)
 	PlaceMob(m, a)
 	PlaceMob(n, b)
 
@@ -69,11 +93,15 @@ func TestMob_Bump(t *testing.T) {
 		got = v
 	}))
 
-	m.Handle(&Move{Vec(1, 0)})
+	move := &Move{Delta: Vec(1, 0)}
+	m.Handle(move)
 
 	if got == nil || got.Bumped != n {
 		t.Error("Mob.Handle(Move) sent incorrect Bump")
 	}
+	if move.Cost != CostAttack {
+		t.Error("Mob.Handle(Move) gave incorrect Cost for Bump")
+	}
 	if a.Occupant != m {
 		t.Error("Mob.Handle(Move) incorrectly set old Occupant")
 	}
@@ -89,7 +117,7 @@ func TestMob_Collide(t *testing.T) {
 	m := NewMob(Ch('@'))
 	a := NewTile(Vec(1, 1))
 	b := NewTile(Vec(2, 0))
-	a.Adjacent[Vec(2, 1)] = b
+	a.Link(Vec(2, 1), b)
 	PlaceMob(m, a)
 	b.Pass = false
 
@@ -98,11 +126,15 @@ func TestMob_Collide(t *testing.T) {
 		got = v
 	}))
 
-	m.Handle(&Move{Vec(2, 1)})
+	move := &Move{Delta: Vec(2, 1)}
+	m.Handle(move)
 
 	if got == nil || got.Obstacle != b {
 		t.Error("Mob.Handle(Move) send incorrect Collide")
 	}
+	if move.Cost != 0 {
+		t.Error("Mob.Handle(Move) gave Cost for Collide")
+	}
 	if a.Occupant != m {
 		t.Error("Mob.Handle(Move) incorrectly set old Occupant")
 	}
@@ -118,7 +150,7 @@ func TestMob_Move(t *testing.T) {
 	m := NewMob(Ch('@'))
 	a := NewTile(Vec(1, 1))
 	b := NewTile(Vec(2, 0))
-	a.Adjacent[Vec(2, 1)] = b
+	a.Link(Vec(2, 1), b)
 	PlaceMob(m, a)
 
 	var gotA *SetOccupant
@@ -134,8 +166,12 @@ func TestMob_Move(t *testing.T) {
 		gotM = v
 	}))
 
-	m.Handle(&Move{Vec(2, 1)})
+	move := &Move{Delta: Vec(2, 1)}
+	m.Handle(move)
 
+	if move.Cost != CostMove {
+		t.Error("Mob.Handle(Move) gave incorrect Cost")
+	}
 	if gotA == nil || gotA.Value != nil {
 		t.Error("Mob.Handle(Move) sent incorrect SetOccupant clear}")
 	}
@@ -167,8 +203,8 @@ func TestNewTile(t *testing.T) {
 	if !a.Pass {
 		t.Error("NewTile produced incorrect Pass")
 	}
-	if a.Adjacent == nil {
-		t.Error("NewTile failed to initialize Adjacent")
+	if a.Adjacent != nil {
+		t.Error("NewTile allocated Adjacent before any Link")
 	}
 }
 
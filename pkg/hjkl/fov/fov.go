@@ -0,0 +1,234 @@
+// Package fov provides field-of-view and line-of-sight over Tile graphs.
+package fov
+
+import "github.com/jefflund/stones/pkg/hjkl"
+
+// Opaque returns true if the Tile blocks sight.
+func Opaque(t *hjkl.Tile) bool {
+	return !t.Pass
+}
+
+// FoV computes the set of Tile visible from an origin Tile within a radius.
+//
+// Visibility is computed with symmetric shadowcasting, so if a Tile b is
+// visible from Tile a, then a is also visible from b. Opaque Tile which bound
+// the field of view are included in the visible set, so walls are seen. The
+// origin is always visible.
+func FoV(origin *hjkl.Tile, radius int) map[*hjkl.Tile]struct{} {
+	visible := map[*hjkl.Tile]struct{}{origin: {}}
+	if radius <= 0 {
+		return visible
+	}
+
+	grid := relativeGrid(origin, radius)
+	for _, q := range quadrants {
+		s := scanner{q, grid, radius, visible}
+		s.scan(1, fraction{-1, 1}, fraction{1, 1})
+	}
+	return visible
+}
+
+// LoS returns true if there is an unobstructed line of sight between two Tile.
+//
+// The line is traced from each endpoint towards the other by following the
+// neighbors of each Tile, so LoS(a, b) == LoS(b, a). Only the Tile
+// strictly between the endpoints are checked for opacity.
+func LoS(a, b *hjkl.Tile) bool {
+	return trace(a, b) || trace(b, a)
+}
+
+// trace returns true if the Bresenham line from src to dst is unobstructed.
+func trace(src, dst *hjkl.Tile) bool {
+	curr := src
+	for _, step := range line(dst.Offset.Sub(src.Offset)) {
+		if curr != src && Opaque(curr) {
+			return false
+		}
+		next, ok := curr.Neighbor(step)
+		if !ok {
+			return false
+		}
+		curr = next
+	}
+	return curr == dst
+}
+
+// line computes the sequence of unit steps in a Bresenham line from the zero
+// Vector to the given delta.
+func line(delta hjkl.Vector) []hjkl.Vector {
+	dx, dy := abs(delta.X), abs(delta.Y)
+	sx, sy := sign(delta.X), sign(delta.Y)
+
+	steps := make([]hjkl.Vector, 0, max(dx, dy))
+	err := dx - dy
+	for x, y := 0, 0; x != delta.X || y != delta.Y; {
+		var step hjkl.Vector
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x += sx
+			step.X = sx
+		}
+		if e2 < dx {
+			err += dx
+			y += sy
+			step.Y = sy
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// relativeGrid maps Vector offsets relative to the origin to Tile by walking
+// the neighbors outward from the origin, stopping at the radius.
+func relativeGrid(origin *hjkl.Tile, radius int) map[hjkl.Vector]*hjkl.Tile {
+	grid := map[hjkl.Vector]*hjkl.Tile{{}: origin}
+	frontier := []hjkl.Vector{{}}
+	for len(frontier) > 0 {
+		pos := frontier[0]
+		frontier = frontier[1:]
+		for delta, adj := range grid[pos].Neighbors() {
+			next := pos.Add(delta)
+			if abs(next.X) > radius || abs(next.Y) > radius {
+				continue
+			}
+			if _, seen := grid[next]; !seen {
+				grid[next] = adj
+				frontier = append(frontier, next)
+			}
+		}
+	}
+	return grid
+}
+
+// quadrant transforms a (depth, col) pair into a Vector relative to the origin.
+type quadrant func(depth, col int) hjkl.Vector
+
+// quadrants contains the four cardinal quadrant transforms.
+var quadrants = []quadrant{
+	func(depth, col int) hjkl.Vector { return hjkl.Vec(col, -depth) },
+	func(depth, col int) hjkl.Vector { return hjkl.Vec(depth, col) },
+	func(depth, col int) hjkl.Vector { return hjkl.Vec(col, depth) },
+	func(depth, col int) hjkl.Vector { return hjkl.Vec(-depth, col) },
+}
+
+// scanner holds the state for shadowcasting a single quadrant.
+type scanner struct {
+	transform quadrant
+	grid      map[hjkl.Vector]*hjkl.Tile
+	radius    int
+	visible   map[*hjkl.Tile]struct{}
+}
+
+// tile gets the Tile at the (depth, col) pair, or nil if there is none.
+func (s *scanner) tile(depth, col int) *hjkl.Tile {
+	return s.grid[s.transform(depth, col)]
+}
+
+// wall returns true if the (depth, col) pair blocks sight. Missing Tile are
+// treated as walls since nothing can be seen through them.
+func (s *scanner) wall(depth, col int) bool {
+	t := s.tile(depth, col)
+	return t == nil || Opaque(t)
+}
+
+// reveal marks the Tile at the (depth, col) pair visible if in the radius.
+func (s *scanner) reveal(depth, col int) {
+	if depth*depth+col*col > s.radius*s.radius {
+		return
+	}
+	if t := s.tile(depth, col); t != nil {
+		s.visible[t] = struct{}{}
+	}
+}
+
+// scan recursively shadowcasts the row at the given depth between two slopes.
+func (s *scanner) scan(depth int, start, end fraction) {
+	if depth > s.radius {
+		return
+	}
+
+	minCol := start.mul(depth).roundUp()
+	maxCol := end.mul(depth).roundDown()
+	prevWall, first := false, true
+	for col := minCol; col <= maxCol; col++ {
+		wall := s.wall(depth, col)
+		if wall || (start.mul(depth).le(col) && end.mul(depth).ge(col)) {
+			s.reveal(depth, col)
+		}
+		if !first && prevWall && !wall {
+			start = slope(depth, col)
+		}
+		if !first && !prevWall && wall {
+			s.scan(depth+1, start, slope(depth, col))
+		}
+		prevWall, first = wall, false
+	}
+	if !first && !prevWall {
+		s.scan(depth+1, start, end)
+	}
+}
+
+// fraction is an exact rational number n/d with d > 0.
+type fraction struct {
+	n, d int
+}
+
+// slope gets the slope to the leading edge of a (depth, col) pair.
+func slope(depth, col int) fraction {
+	return fraction{2*col - 1, 2 * depth}
+}
+
+// mul returns the product of the fraction and an int.
+func (f fraction) mul(k int) fraction {
+	return fraction{f.n * k, f.d}
+}
+
+// le returns true if the fraction is less than or equal to an int.
+func (f fraction) le(k int) bool {
+	return f.n <= k*f.d
+}
+
+// ge returns true if the fraction is greater than or equal to an int.
+func (f fraction) ge(k int) bool {
+	return f.n >= k*f.d
+}
+
+// roundUp rounds the fraction to the nearest int, with ties rounded up.
+func (f fraction) roundUp() int {
+	return floorDiv(2*f.n+f.d, 2*f.d)
+}
+
+// roundDown rounds the fraction to the nearest int, with ties rounded down.
+func (f fraction) roundDown() int {
+	return -floorDiv(-2*f.n+f.d, 2*f.d)
+}
+
+// floorDiv divides two ints, rounding towards negative infinity.
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// abs returns the absolute value of an int.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// sign returns -1, 0, or 1 depending on the sign of an int.
+func sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	default:
+		return 0
+	}
+}
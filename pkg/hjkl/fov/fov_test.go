@@ -0,0 +1,117 @@
+package fov
+
+import (
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/gen"
+	"github.com/jefflund/stones/pkg/hjkl/rand"
+)
+
+func TestFoV_Open(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		".........",
+		".........",
+		".........",
+		".........",
+		".........",
+		".........",
+		".........",
+	})
+	visible := FoV(level.At(hjkl.Vec(4, 3)), 2)
+	for _, tile := range level.Tiles {
+		d := tile.Offset.Sub(hjkl.Vec(4, 3))
+		_, got := visible[tile]
+		if want := d.X*d.X+d.Y*d.Y <= 4; got != want {
+			t.Errorf("FoV visibility of %v was %v", tile.Offset, got)
+		}
+	}
+}
+
+func TestFoV_Walls(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		".......",
+		"...#...",
+		".......",
+		".#...#.",
+		".......",
+	})
+	visible := FoV(level.At(hjkl.Vec(3, 3)), 10)
+	cases := []struct {
+		Pos  hjkl.Vector
+		Want bool
+	}{
+		{hjkl.Vec(3, 3), true},
+		{hjkl.Vec(3, 1), true},
+		{hjkl.Vec(3, 0), false},
+		{hjkl.Vec(1, 3), true},
+		{hjkl.Vec(0, 3), false},
+		{hjkl.Vec(5, 3), true},
+		{hjkl.Vec(6, 3), false},
+		{hjkl.Vec(0, 0), true},
+		{hjkl.Vec(6, 4), true},
+	}
+	for _, c := range cases {
+		if _, got := visible[level.At(c.Pos)]; got != c.Want {
+			t.Errorf("FoV visibility of %v was %v", c.Pos, got)
+		}
+	}
+}
+
+func TestFoV_Symmetric(t *testing.T) {
+	rand.Seed(0xBAAAAAAD)
+	tiles := gen.GenTileGrid(20, 20, func(v hjkl.Vector) *hjkl.Tile {
+		t := hjkl.NewTile(v)
+		t.Pass = !rand.Chance(0.2)
+		return t
+	})
+	views := make(map[*hjkl.Tile]map[*hjkl.Tile]struct{})
+	for _, tile := range tiles {
+		if tile.Pass {
+			views[tile] = FoV(tile, 8)
+		}
+	}
+	for a, view := range views {
+		for b := range view {
+			if _, ok := views[b]; !ok {
+				continue
+			}
+			if _, ok := views[b][a]; !ok {
+				t.Fatalf("FoV from %v sees %v, but not vice versa", a.Offset, b.Offset)
+			}
+		}
+	}
+}
+
+func TestLoS(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		".......",
+		"...#...",
+		".......",
+		".#...#.",
+		".......",
+	})
+	cases := []struct {
+		A, B hjkl.Vector
+		Want bool
+	}{
+		{hjkl.Vec(3, 3), hjkl.Vec(3, 3), true},
+		{hjkl.Vec(3, 3), hjkl.Vec(3, 2), true},
+		{hjkl.Vec(3, 3), hjkl.Vec(3, 1), true},
+		{hjkl.Vec(3, 3), hjkl.Vec(3, 0), false},
+		{hjkl.Vec(0, 3), hjkl.Vec(6, 3), false},
+		{hjkl.Vec(0, 4), hjkl.Vec(6, 4), true},
+		{hjkl.Vec(0, 0), hjkl.Vec(6, 4), false},
+		{hjkl.Vec(0, 0), hjkl.Vec(3, 3), true},
+		{hjkl.Vec(2, 0), hjkl.Vec(4, 2), false},
+		{hjkl.Vec(0, 2), hjkl.Vec(6, 2), true},
+	}
+	for _, c := range cases {
+		if got := LoS(level.At(c.A), level.At(c.B)); got != c.Want {
+			t.Errorf("LoS(%v, %v) = %v", c.A, c.B, got)
+		}
+		if got := LoS(level.At(c.B), level.At(c.A)); got != c.Want {
+			t.Errorf("LoS(%v, %v) = %v", c.B, c.A, got)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package fov
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/save"
+)
+
+func init() {
+	save.Register[Memory]("fov.Memory")
+}
+
+// Memory is a Component which tracks what a Mob sees and remembers seeing.
+// Memory implements hjkl.View, so it can be used to render fog-of-war.
+type Memory struct {
+	Radius  int
+	Seen    map[*hjkl.Tile]struct{}
+	Terrain map[*hjkl.Tile]hjkl.Glyph
+}
+
+// NewMemory creates an empty Memory with the given sight radius.
+func NewMemory(radius int) *Memory {
+	return &Memory{
+		Radius:  radius,
+		Seen:    make(map[*hjkl.Tile]struct{}),
+		Terrain: make(map[*hjkl.Tile]hjkl.Glyph),
+	}
+}
+
+// Handle updates the Memory whenever the Mob changes position.
+func (m *Memory) Handle(e *hjkl.Mob, v hjkl.Event) {
+	if _, ok := v.(*hjkl.SetPos); ok {
+		m.Update(e.Pos)
+	}
+}
+
+// Update recomputes the visible Tile from the origin, and records the terrain
+// of each visible Tile. The Occupant of a Tile is never remembered.
+func (m *Memory) Update(origin *hjkl.Tile) {
+	if origin == nil {
+		m.Seen = make(map[*hjkl.Tile]struct{})
+		return
+	}
+	m.Seen = FoV(origin, m.Radius)
+	for t := range m.Seen {
+		m.Terrain[t] = t.Face
+	}
+}
+
+// Visible returns true if the Tile is currently in view.
+func (m *Memory) Visible(t *hjkl.Tile) bool {
+	_, ok := m.Seen[t]
+	return ok
+}
+
+// Remembered gets the last seen terrain Glyph of a Tile, if it was ever seen.
+func (m *Memory) Remembered(t *hjkl.Tile) (hjkl.Glyph, bool) {
+	g, ok := m.Terrain[t]
+	return g, ok
+}
+
+// memoryData is the serialized form of Memory.
+type memoryData struct {
+	Radius  int
+	Seen    []int
+	Terrain map[int]hjkl.Glyph
+}
+
+// MarshalState implements save.Marshaler for Memory.
+func (m *Memory) MarshalState(r *save.Refs) ([]byte, error) {
+	data := memoryData{Radius: m.Radius, Terrain: make(map[int]hjkl.Glyph)}
+	for t := range m.Seen {
+		data.Seen = append(data.Seen, r.TileID(t))
+	}
+	slices.Sort(data.Seen)
+	for t, g := range m.Terrain {
+		data.Terrain[r.TileID(t)] = g
+	}
+	return json.Marshal(data)
+}
+
+// UnmarshalState implements save.Unmarshaler for Memory.
+func (m *Memory) UnmarshalState(r *save.Refs, b []byte) error {
+	var data memoryData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	*m = *NewMemory(data.Radius)
+	for _, id := range data.Seen {
+		m.Seen[r.Tile(id)] = struct{}{}
+	}
+	for id, g := range data.Terrain {
+		m.Terrain[r.Tile(id)] = g
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package fov
+
+import (
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/gen"
+)
+
+func TestMemory(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		"....#.....",
+	})
+	m := hjkl.NewMob(hjkl.Ch('@'))
+	mem := NewMemory(10)
+	m.Components.Add(mem)
+	hjkl.PlaceMob(m, level.At(hjkl.Vec(2, 0)))
+	mem.Update(m.Pos)
+
+	if !mem.Visible(level.At(hjkl.Vec(0, 0))) || !mem.Visible(level.At(hjkl.Vec(4, 0))) {
+		t.Error("Memory failed to see open Tile")
+	}
+	if mem.Visible(level.At(hjkl.Vec(5, 0))) {
+		t.Error("Memory saw through wall")
+	}
+
+	level.At(hjkl.Vec(0, 0)).Face = hjkl.Ch('+')
+	hjkl.PlaceMob(hjkl.NewMob(hjkl.Ch('D')), level.At(hjkl.Vec(1, 0)))
+	mem.Update(m.Pos)
+	level.At(hjkl.Vec(4, 0)).Pass = true
+	m.Handle(&hjkl.Move{Delta: hjkl.Vec(1, 0)})
+	m.Handle(&hjkl.Move{Delta: hjkl.Vec(1, 0)})
+	level.At(hjkl.Vec(4, 0)).Pass = false
+	m.Handle(&hjkl.Move{Delta: hjkl.Vec(1, 0)})
+	level.At(hjkl.Vec(0, 0)).Face = hjkl.Ch('#')
+
+	if mem.Visible(level.At(hjkl.Vec(0, 0))) {
+		t.Error("Memory failed to update on Move")
+	}
+	if !mem.Visible(level.At(hjkl.Vec(8, 0))) {
+		t.Error("Memory failed to see newly opened Tile")
+	}
+	if g, ok := mem.Remembered(level.At(hjkl.Vec(0, 0))); !ok || g != hjkl.Ch('+') {
+		t.Error("Memory failed to remember last seen terrain", g)
+	}
+	if g, ok := mem.Remembered(level.At(hjkl.Vec(1, 0))); !ok || g != hjkl.Ch('.') {
+		t.Error("Memory remembered Occupant instead of terrain", g)
+	}
+}
@@ -16,7 +16,7 @@ func GenTileGrid(cols, rows int, f func(hjkl.Vector) *hjkl.Tile) []*hjkl.Tile {
 	for off, src := range grid {
 		for _, delta := range hjkl.CompassDirs {
 			if dst, ok := grid[off.Add(delta)]; ok {
-				src.Adjacent[delta] = dst
+				src.Link(delta, dst)
 			}
 		}
 	}
@@ -28,10 +28,36 @@ func GenTileGrid(cols, rows int, f func(hjkl.Vector) *hjkl.Tile) []*hjkl.Tile {
 	return tiles
 }
 
-// GenFence applies a function to any Tile which is not 8-connected.
+// GenLevel creates a Level with the given number of columns and rows. Unlike
+// GenTileGrid, the Tile need no Adjacent links since the Level provides them.
+func GenLevel(cols, rows int, f func(hjkl.Vector) *hjkl.Tile) *hjkl.Level {
+	return hjkl.NewLevel(cols, rows, f)
+}
+
+// GenLevelMap creates a Level from a map with one string per row, in which
+// '#' is an impassable wall and anything else is open floor.
+func GenLevelMap(rows []string) *hjkl.Level {
+	return GenLevel(len(rows[0]), len(rows), func(v hjkl.Vector) *hjkl.Tile {
+		t := hjkl.NewTile(v)
+		if rows[v.Y][v.X] == '#' {
+			t.Face = hjkl.Ch('#')
+			t.Pass = false
+		}
+		return t
+	})
+}
+
+// GenFence applies a function to any Tile which is not 8-connected. Since a
+// Level exposes its Tile, GenFence(level.Tiles, f) fences a Level.
 func GenFence(tiles []*hjkl.Tile, f func(*hjkl.Tile)) {
 	for _, t := range tiles {
-		if len(t.Adjacent) != 8 {
+		n := 0
+		for _, delta := range hjkl.CompassDirs {
+			if _, ok := t.Neighbor(delta); ok {
+				n++
+			}
+		}
+		if n != 8 {
 			f(t)
 		}
 	}
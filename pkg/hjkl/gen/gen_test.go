@@ -52,3 +52,36 @@ func TestGenFence(t *testing.T) {
 		}
 	}
 }
+
+func TestGenLevel(t *testing.T) {
+	const W, H = 10, 5
+
+	level := GenLevel(W, H, hjkl.NewTile)
+	GenFence(level.Tiles, func(t *hjkl.Tile) {
+		t.Pass = false
+	})
+
+	for _, src := range level.Tiles {
+		bound := src.Offset.X == 0 || src.Offset.X == W-1 || src.Offset.Y == 0 || src.Offset.Y == H-1
+		if bound == src.Pass {
+			t.Error("GenFence failed to fence Level")
+		}
+	}
+}
+
+func TestGenLevelMap(t *testing.T) {
+	rows := []string{
+		"..#",
+		"#..",
+	}
+	level := GenLevelMap(rows)
+	if level.Size != hjkl.Vec(3, 2) {
+		t.Fatalf("GenLevelMap gave Size %v", level.Size)
+	}
+	for _, tile := range level.Tiles {
+		wall := rows[tile.Offset.Y][tile.Offset.X] == '#'
+		if tile.Pass == wall || (tile.Face.Ch == '#') != wall {
+			t.Errorf("GenLevelMap gave incorrect Tile at %v", tile.Offset)
+		}
+	}
+}
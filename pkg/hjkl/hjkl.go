@@ -3,12 +3,13 @@ package hjkl
 
 import (
 	"errors"
+	"io"
 	"time"
 )
 
 // Game contains the functionality needed to run a game.
 type Game interface {
-	Update([]Key) error
+	Update([]Input) error
 	Draw(Canvas)
 }
 
@@ -19,6 +20,8 @@ var Termination = errors.New("Termination")
 type RunConfig struct {
 	Terminal Terminal
 	TPS      int
+	Record   io.Writer
+	Seed     uint64
 }
 
 // DefaultRunConfig creates a RunConfig with default settings.
@@ -39,7 +42,9 @@ func WithTerm(t Terminal) RunOption {
 	}
 }
 
-// WithTPS gets a RunOption which sets the TPS of a RunConfig.
+// WithTPS gets a RunOption which sets the TPS of a RunConfig. A TPS of zero or
+// less runs ticks as fast as possible, which is only sensible when the
+// Terminal implements TickInput (e.g., when running a Replay).
 func WithTPS(tps int) RunOption {
 	return func(r *RunConfig) {
 		r.TPS = tps
@@ -47,11 +52,12 @@ func WithTPS(tps int) RunOption {
 }
 
 // Run runs a game. Each tick Run calls both Update and Draw. Update updates
-// the game state using the keypresses since the last tick. If Update returns
+// the game state using the Input since the last tick. If the Game implements
+// Resizer, any InputResize is given to Resize before Update. If Update returns
 // nil, Run will continue execution by calling Draw. If Update returns
 // Termination, Run will terminate without error. All other non-nil errors
 // result in Run terminating with an error.
-func Run(g Game, opts ...RunOption) error {
+func Run(g Game, opts ...RunOption) (err error) {
 	// Apply config options.
 	config := DefaultRunConfig()
 	for _, opt := range opts {
@@ -65,23 +71,73 @@ func Run(g Game, opts ...RunOption) error {
 	}
 	input := term.Input()
 	defer term.Done()
+	ticks, _ := term.(TickInput)
 
-	// Setup a ticker to trigger Update and Draw.
-	ticker := time.NewTicker(time.Second / time.Duration(config.TPS))
-	defer ticker.Stop()
+	// Give the Game the initial size, if it wants to know.
+	resizer, _ := g.(Resizer)
+	if resizer != nil {
+		resizer.Resize(term.Size())
+	}
+
+	// Setup recording of each tick of input, if requested.
+	var rec *recorder
+	if config.Record != nil {
+		if rec, err = newRecorder(config.Record, config.Seed, term.Size()); err != nil {
+			return err
+		}
+		defer func() {
+			if doneErr := rec.done(); err == nil {
+				err = doneErr
+			}
+		}()
+	}
+
+	// Setup a ticker to trigger Update and Draw. Without a positive TPS, a
+	// closed channel is used instead so that every tick is immediately ready.
+	var tick <-chan time.Time
+	if config.TPS > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(config.TPS))
+		defer ticker.Stop()
+		tick = ticker.C
+	} else {
+		ready := make(chan time.Time)
+		close(ready)
+		tick = ready
+	}
 
-	// Slice to accumulate keypresses each tick.
-	var keys []Key
+	// Slice to accumulate Input each tick.
+	var inputs []Input
 
 	// Run the actual game loop.
 	for {
 		select {
-		case <-ticker.C:
+		case <-tick:
+			// If the Terminal supplies input by tick, use it instead of Input.
+			if ticks != nil {
+				batch, ok := ticks.NextTick()
+				if !ok {
+					return nil
+				}
+				inputs = append(inputs[:0], batch...)
+			}
+			if rec != nil {
+				if err := rec.record(inputs); err != nil {
+					return err
+				}
+			}
+			if resizer != nil {
+				for _, in := range inputs {
+					if in.Kind == InputResize {
+						resizer.Resize(in.Pos)
+					}
+				}
+			}
+
 			// Each tick, run Update and then either Draw or terminate.
-			switch err := g.Update(keys); err {
+			switch err := g.Update(inputs); err {
 			case nil:
-				// Reset keys (in-place to avoid allocs) now they've been used.
-				keys = keys[:0]
+				// Reset inputs (in-place to avoid allocs) now they're used.
+				inputs = inputs[:0]
 				term.Clear()
 				g.Draw(term) // Game only gets Canvas part of Terminal.
 				if err := term.Flush(); err != nil {
@@ -94,10 +150,10 @@ func Run(g Game, opts ...RunOption) error {
 				// All other errors are actual errors, so return them.
 				return err
 			}
-		case key := <-input:
-			// We could append to keys in the polling goroutine, but doing
-			// inside the select coordinates access to keys with the ticker.
-			keys = append(keys, key)
+		case in := <-input:
+			// We could append to inputs in the polling goroutine, but doing
+			// inside the select coordinates access to inputs with the ticker.
+			inputs = append(inputs, in)
 		}
 	}
 }
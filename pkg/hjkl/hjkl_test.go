@@ -8,25 +8,27 @@ import (
 )
 
 type MockGame struct {
-	UpdateFn func([]Key) error
+	UpdateFn func([]Input) error
 	DrawFn   func(Canvas)
 }
 
-func (m *MockGame) Update(ks []Key) error { return m.UpdateFn(ks) }
-func (m *MockGame) Draw(c Canvas)         { m.DrawFn(c) }
+func (m *MockGame) Update(ks []Input) error { return m.UpdateFn(ks) }
+func (m *MockGame) Draw(c Canvas)           { m.DrawFn(c) }
 
 type MockTerm struct {
 	InitFn  func() error
-	InputFn func() chan Key
+	InputFn func() chan Input
 	DoneFn  func()
+	SizeFn  func() Vector
 	ClearFn func()
 	BlitFn  func(Vector, Glyph)
 	FlushFn func() error
 }
 
 func (m *MockTerm) Init() error            { return m.InitFn() }
-func (m *MockTerm) Input() chan Key        { return m.InputFn() }
+func (m *MockTerm) Input() chan Input      { return m.InputFn() }
 func (m *MockTerm) Done()                  { m.DoneFn() }
+func (m *MockTerm) Size() Vector           { return m.SizeFn() }
 func (m *MockTerm) Clear()                 { m.ClearFn() }
 func (m *MockTerm) Blit(v Vector, g Glyph) { m.BlitFn(v, g) }
 func (m *MockTerm) Flush() error           { return m.FlushFn() }
@@ -40,16 +42,16 @@ func TestRun(t *testing.T) {
 	var update Key
 	player := make(chan struct{})
 	game := &MockGame{
-		UpdateFn: func(ks []Key) error {
+		UpdateFn: func(ks []Input) error {
 			if len(ks) == 0 {
 				return nil
 			}
-			log = append(log, fmt.Sprintf("Update(%d)", ks[0]))
+			log = append(log, fmt.Sprintf("Update(%d)", ks[0].Key))
 			for _, k := range ks {
-				if k == KeyEsc {
+				if k.Key == KeyEsc {
 					return Termination
 				}
-				update = k
+				update = k.Key
 			}
 			return nil
 		},
@@ -65,13 +67,13 @@ func TestRun(t *testing.T) {
 			log = append(log, "Init")
 			return nil
 		},
-		InputFn: func() chan Key {
+		InputFn: func() chan Input {
 			log = append(log, "Input")
-			c := make(chan Key)
+			c := make(chan Input)
 			go func() {
 				ks := []Key{'l', 'h', KeyEsc}
 				for _, k := range ks {
-					c <- k
+					c <- KeyPress(k)
 					<-player
 				}
 			}()
@@ -133,12 +135,12 @@ func TestRun_InitError(t *testing.T) {
 func TestRun_FlushError(t *testing.T) {
 	want := errors.New("Flush Error")
 	game := &MockGame{
-		UpdateFn: func([]Key) error { return nil },
+		UpdateFn: func([]Input) error { return nil },
 		DrawFn:   func(Canvas) {},
 	}
 	term := &MockTerm{
 		InitFn:  func() error { return want },
-		InputFn: func() chan Key { return nil },
+		InputFn: func() chan Input { return nil },
 		ClearFn: func() {},
 		FlushFn: func() error { return want },
 	}
@@ -150,11 +152,11 @@ func TestRun_FlushError(t *testing.T) {
 func TestRun_UpdateError(t *testing.T) {
 	want := errors.New("Update Error")
 	game := &MockGame{
-		UpdateFn: func([]Key) error { return want },
+		UpdateFn: func([]Input) error { return want },
 	}
 	term := &MockTerm{
 		InitFn:  func() error { return want },
-		InputFn: func() chan Key { return nil },
+		InputFn: func() chan Input { return nil },
 		ClearFn: func() {},
 	}
 	if err := Run(game, WithTerm(term)); err != want {
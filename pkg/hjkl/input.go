@@ -0,0 +1,111 @@
+package hjkl
+
+import "unicode"
+
+// InputKind describes which kind of event an Input represents.
+type InputKind uint8
+
+// InputKind constants for use with Input.
+const (
+	InputKey InputKind = iota
+	InputMouse
+	InputResize
+)
+
+// Mod is a bitmask of keyboard modifiers held during an Input.
+type Mod uint8
+
+// Mod constants for use with Input.
+const (
+	ModShift Mod = 1 << iota
+	ModAlt
+	ModCtrl
+)
+
+// MouseButton describes the mouse action of an Input.
+type MouseButton uint8
+
+// MouseButton constants for use with Input.
+const (
+	MouseNone MouseButton = iota
+	MouseLeft
+	MouseMiddle
+	MouseRight
+	MouseRelease
+	MouseWheelUp
+	MouseWheelDown
+)
+
+// Input is a single input event from a Terminal.
+//
+// For InputKey, Key is the key code, which for character keys is the
+// character itself and for special keys is one of the Key constants. Rune is
+// the printable character typed, or 0 for special keys. For InputMouse, Mouse
+// is the button and Pos is the cell position. For InputResize, Pos is the new
+// size of the Terminal. Mod is valid for both InputKey and InputMouse.
+type Input struct {
+	Kind  InputKind
+	Key   Key
+	Rune  rune
+	Mod   Mod
+	Mouse MouseButton
+	Pos   Vector
+}
+
+// keySpecialStart is the lowest Key code reserved for special keys.
+const keySpecialStart Key = 0xFFFF - 31
+
+// KeyPress creates an InputKey Input for a Key without modifiers.
+func KeyPress(k Key) Input {
+	in := Input{Kind: InputKey, Key: k}
+	if r := rune(k); k < keySpecialStart && unicode.IsPrint(r) {
+		in.Rune = r
+	}
+	return in
+}
+
+// controlMod gets ModCtrl for control character Key, or 0 otherwise. Tab,
+// Enter and Esc are control characters, but have their own keys.
+func controlMod(k Key) Mod {
+	if k < 0x20 && k != KeyTab && k != KeyEnter && k != KeyEsc {
+		return ModCtrl
+	}
+	return 0
+}
+
+// ArrowKeyDirs is a mapping of arrow Key to CompassDirs. Since a numpad with
+// numlock off sends Home, End, Pgup and Pgdn on the diagonals, those Key are
+// included as well.
+var ArrowKeyDirs = map[Key]Vector{
+	KeyArrowLeft:  {-1, 0},
+	KeyArrowDown:  {0, 1},
+	KeyArrowUp:    {0, -1},
+	KeyArrowRight: {1, 0},
+	KeyPgdn:       {1, 1},
+	KeyEnd:        {-1, 1},
+	KeyPgup:       {1, -1},
+	KeyHome:       {-1, -1},
+}
+
+// NumpadKeyDirs is a mapping of numpad Key (with numlock on) to CompassDirs.
+var NumpadKeyDirs = map[Key]Vector{
+	'4': {-1, 0},
+	'2': {0, 1},
+	'8': {0, -1},
+	'6': {1, 0},
+	'3': {1, 1},
+	'1': {-1, 1},
+	'9': {1, -1},
+	'7': {-1, -1},
+}
+
+// KeyDir gets the direction for a Key using VIKeyDirs, ArrowKeyDirs or
+// NumpadKeyDirs.
+func KeyDir(k Key) (Vector, bool) {
+	for _, dirs := range []map[Key]Vector{VIKeyDirs, ArrowKeyDirs, NumpadKeyDirs} {
+		if dir, ok := dirs[k]; ok {
+			return dir, true
+		}
+	}
+	return Vector{}, false
+}
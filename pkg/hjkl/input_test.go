@@ -0,0 +1,44 @@
+package hjkl
+
+import "testing"
+
+func TestKeyPress(t *testing.T) {
+	cases := []struct {
+		Key  Key
+		Rune rune
+	}{
+		{'a', 'a'},
+		{'é', 'é'},
+		{KeyEnter, 0},
+		{KeyEsc, 0},
+		{KeyArrowUp, 0},
+		{KeyF1, 0},
+	}
+	for _, c := range cases {
+		in := KeyPress(c.Key)
+		if in.Kind != InputKey || in.Key != c.Key || in.Rune != c.Rune || in.Mod != 0 {
+			t.Errorf("KeyPress(%d) = %v", c.Key, in)
+		}
+	}
+}
+
+func TestKeyDir(t *testing.T) {
+	cases := []struct {
+		Key Key
+		Dir Vector
+		Ok  bool
+	}{
+		{'h', Vec(-1, 0), true},
+		{'n', Vec(1, 1), true},
+		{KeyArrowUp, Vec(0, -1), true},
+		{KeyPgdn, Vec(1, 1), true},
+		{'7', Vec(-1, -1), true},
+		{'5', Vector{}, false},
+		{'x', Vector{}, false},
+	}
+	for _, c := range cases {
+		if dir, ok := KeyDir(c.Key); dir != c.Dir || ok != c.Ok {
+			t.Errorf("KeyDir(%d) = %v, %v", c.Key, dir, ok)
+		}
+	}
+}
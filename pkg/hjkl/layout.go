@@ -0,0 +1,77 @@
+package hjkl
+
+// Resizer is implemented by anything which needs to know the Terminal size,
+// such as a Screen which lays out its Widget. If a Game implements Resizer,
+// Run calls Resize with the initial size and again whenever it changes.
+type Resizer interface {
+	Resize(size Vector)
+}
+
+// Edge is a coordinate relative to the size of a parent, given as a percent of
+// the parent size plus an absolute offset in cells.
+type Edge struct {
+	Pct int
+	Off int
+}
+
+// Abs creates an Edge which is an absolute number of cells.
+func Abs(n int) Edge {
+	return Edge{Off: n}
+}
+
+// Pct creates an Edge which is a percent of the parent size.
+func Pct(p int) Edge {
+	return Edge{Pct: p}
+}
+
+// Plus creates an Edge which is offset by an additional number of cells.
+func (d Edge) Plus(n int) Edge {
+	return Edge{d.Pct, d.Off + n}
+}
+
+// Resolve computes the Edge for the given parent size, clamped to the parent.
+func (d Edge) Resolve(n int) int {
+	return max(0, min(n, d.Pct*n/100+d.Off))
+}
+
+// Layout positions a rectangle within a parent by anchoring each of its edges.
+// Left and Right are resolved against the parent width, while Top and Bottom
+// are resolved against the parent height. Right and Bottom are exclusive, so
+// Layout{Pct(0), Pct(0), Pct(100), Pct(100)} fills the parent.
+type Layout struct {
+	Left, Top, Right, Bottom Edge
+}
+
+// Fill is a Layout which covers the entire parent.
+var Fill = Layout{Pct(0), Pct(0), Pct(100), Pct(100)}
+
+// Resolve computes the position and size of the Layout for the given parent
+// size. Edges which cross give an empty size rather than a negative one.
+func (l Layout) Resolve(size Vector) (pos, dims Vector) {
+	pos = Vec(l.Left.Resolve(size.X), l.Top.Resolve(size.Y))
+	end := Vec(l.Right.Resolve(size.X), l.Bottom.Resolve(size.Y))
+	return pos, Vec(max(0, end.X-pos.X), max(0, end.Y-pos.Y))
+}
+
+// Placeable is a Widget which can be moved and resized. Any Widget which
+// embeds a Window is Placeable.
+type Placeable interface {
+	Widget
+	Place(pos, size Vector)
+}
+
+// Anchored is a Widget which is placed by a Layout whenever it is resized.
+type Anchored struct {
+	Placeable
+	Layout Layout
+}
+
+// NewAnchored creates an Anchored with the given Placeable and Layout.
+func NewAnchored(w Placeable, l Layout) *Anchored {
+	return &Anchored{w, l}
+}
+
+// Resize places the Widget according to the Layout.
+func (a *Anchored) Resize(size Vector) {
+	a.Place(a.Layout.Resolve(size))
+}
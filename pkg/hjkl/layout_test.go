@@ -0,0 +1,81 @@
+package hjkl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLayout_Resolve(t *testing.T) {
+	cases := []struct {
+		Layout    Layout
+		Size      Vector
+		Pos, Dims Vector
+	}{
+		{Fill, Vec(80, 24), Vec(0, 0), Vec(80, 24)},
+		{Layout{Abs(0), Pct(100).Plus(-1), Pct(100), Pct(100)}, Vec(80, 24), Vec(0, 23), Vec(80, 1)},
+		{Layout{Pct(50), Abs(1), Pct(100).Plus(-1), Pct(50)}, Vec(100, 30), Vec(50, 1), Vec(49, 14)},
+		{Layout{Abs(5), Abs(5), Abs(10), Abs(10)}, Vec(8, 20), Vec(5, 5), Vec(3, 5)},
+		{Layout{Abs(10), Abs(0), Abs(5), Pct(100)}, Vec(20, 20), Vec(10, 0), Vec(0, 20)},
+	}
+	for _, c := range cases {
+		if pos, dims := c.Layout.Resolve(c.Size); pos != c.Pos || dims != c.Dims {
+			t.Errorf("%v.Resolve(%v) = %v, %v", c.Layout, c.Size, pos, dims)
+		}
+	}
+}
+
+func TestScreen_Resize(t *testing.T) {
+	tiles := NewTilesWidget(Vector{}, Vector{}, nil)
+	status := NewTilesWidget(Vector{}, Vector{}, nil)
+	fixed := NewTilesWidget(Vec(1, 2), Vec(3, 4), nil)
+	screen := Screen{
+		NewAnchored(tiles, Layout{Abs(0), Abs(0), Pct(100), Pct(100).Plus(-1)}),
+		Screen{NewAnchored(status, Layout{Abs(0), Pct(100).Plus(-1), Pct(100), Pct(100)})},
+		fixed,
+	}
+
+	screen.Resize(Vec(80, 24))
+	if tiles.Pos != Vec(0, 0) || tiles.Size != Vec(80, 23) {
+		t.Errorf("Screen.Resize placed tiles at %v with size %v", tiles.Pos, tiles.Size)
+	}
+	if status.Pos != Vec(0, 23) || status.Size != Vec(80, 1) {
+		t.Errorf("Screen.Resize placed status at %v with size %v", status.Pos, status.Size)
+	}
+
+	screen.Resize(Vec(100, 40))
+	if tiles.Size != Vec(100, 39) || status.Pos != Vec(0, 39) {
+		t.Error("Screen.Resize did not reflow after resize")
+	}
+	if fixed.Pos != Vec(1, 2) || fixed.Size != Vec(3, 4) {
+		t.Error("Screen.Resize moved a Widget without a Layout")
+	}
+}
+
+type MockResizeGame struct {
+	MockGame
+	sizes []Vector
+}
+
+func (g *MockResizeGame) Resize(size Vector) {
+	g.sizes = append(g.sizes, size)
+}
+
+func TestRun_Resize(t *testing.T) {
+	term := NewMemTerminal(Vec(4, 3))
+	term.Send()
+	term.SendInput(Input{Kind: InputResize, Pos: Vec(6, 2)})
+	game := &MockResizeGame{MockGame: MockGame{
+		UpdateFn: func([]Input) error { return nil },
+		DrawFn:   func(c Canvas) { c.Blit(Vec(5, 1), Ch('@')) },
+	}}
+
+	if err := Run(game, WithTerm(term), WithTPS(0)); err != nil {
+		t.Fatal("Run gave error", err)
+	}
+	if want := []Vector{Vec(4, 3), Vec(6, 2)}; !reflect.DeepEqual(game.sizes, want) {
+		t.Errorf("Run gave sizes %v instead of %v", game.sizes, want)
+	}
+	if term.Size() != Vec(6, 2) || term.At(Vec(5, 1)) != Ch('@') {
+		t.Error("MemTerminal was not resized by InputResize")
+	}
+}
@@ -0,0 +1,85 @@
+package hjkl
+
+import "iter"
+
+// Level is a rectangular map of Tile, stored densely in row-major order, along
+// with a Roster of the Mob which live on it, and the Sink for Event published
+// about them. Any game systems which act on the Mob, such as timed effects,
+// are kept in Systems so Component can find them from the Tile of their Mob.
+//
+// Each Tile in a Level neighbors the Tile around it without needing any
+// Adjacent links, and any Tile may be looked up by its offset in constant
+// time. Tile offsets run from the zero Vector up to, but not including, Size.
+type Level struct {
+	Size    Vector
+	Tiles   []*Tile
+	Mobs    *Roster
+	Events  Sink
+	Systems []any
+}
+
+// NewLevel creates a Level with the given number of columns and rows, with each
+// Tile created by calling f with its offset. Any Mob occupying the Tile are
+// added to the Mobs. The Level discards any published Event until Events is
+// replaced.
+func NewLevel(cols, rows int, f func(Vector) *Tile) *Level {
+	l := &Level{
+		Size:   Vec(cols, rows),
+		Tiles:  make([]*Tile, 0, cols*rows),
+		Mobs:   NewRoster(),
+		Events: Discard,
+	}
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			t := f(Vec(x, y))
+			t.level = l
+			l.Tiles = append(l.Tiles, t)
+			if t.Occupant != nil {
+				l.Mobs.Add(t.Occupant)
+			}
+		}
+	}
+	return l
+}
+
+// Publish publishes an Event to Events. A nil Level discards the Event, as does
+// a Level with nil Events.
+func (l *Level) Publish(v Event) {
+	if l != nil && l.Events != nil {
+		l.Events.Publish(v)
+	}
+}
+
+// Contains determines whether the offset is within the bounds of the Level.
+func (l *Level) Contains(v Vector) bool {
+	return 0 <= v.X && v.X < l.Size.X && 0 <= v.Y && v.Y < l.Size.Y
+}
+
+// At gets the Tile at the given offset, or nil if it is out of bounds.
+func (l *Level) At(v Vector) *Tile {
+	if !l.Contains(v) {
+		return nil
+	}
+	return l.Tiles[v.Y*l.Size.X+v.X]
+}
+
+// All iterates over every Tile in the Level in row-major order.
+func (l *Level) All() iter.Seq[*Tile] {
+	return l.Region(Vector{}, l.Size)
+}
+
+// Region iterates in row-major order over the Tile with offsets from lo up to,
+// but not including, hi. The region is clipped to the bounds of the Level.
+func (l *Level) Region(lo, hi Vector) iter.Seq[*Tile] {
+	lo = Vec(max(lo.X, 0), max(lo.Y, 0))
+	hi = Vec(min(hi.X, l.Size.X), min(hi.Y, l.Size.Y))
+	return func(yield func(*Tile) bool) {
+		for y := lo.Y; y < hi.Y; y++ {
+			for x := lo.X; x < hi.X; x++ {
+				if !yield(l.Tiles[y*l.Size.X+x]) {
+					return
+				}
+			}
+		}
+	}
+}
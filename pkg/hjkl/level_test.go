@@ -0,0 +1,133 @@
+package hjkl
+
+import "testing"
+
+func TestNewLevel(t *testing.T) {
+	m := NewMob(Ch('@'))
+	level := NewLevel(4, 3, func(v Vector) *Tile {
+		t := NewTile(v)
+		if v == Vec(2, 1) {
+			PlaceMob(m, t)
+		}
+		return t
+	})
+
+	if level.Size != Vec(4, 3) || len(level.Tiles) != 12 {
+		t.Fatal("NewLevel gave incorrect size")
+	}
+	for i, tile := range level.Tiles {
+		if tile.Offset != Vec(i%4, i/4) {
+			t.Error("NewLevel failed to store Tile in row-major order")
+		}
+		if tile.Adjacent != nil {
+			t.Error("NewLevel allocated Adjacent")
+		}
+	}
+	if !level.Mobs.Contains(m) || level.Mobs.Len() != 1 {
+		t.Error("NewLevel failed to add occupant to Mobs")
+	}
+}
+
+func TestLevel_At(t *testing.T) {
+	level := NewLevel(4, 3, NewTile)
+	cases := []struct {
+		Offset Vector
+		Found  bool
+	}{
+		{Vec(0, 0), true},
+		{Vec(3, 2), true},
+		{Vec(1, 2), true},
+		{Vec(4, 0), false},
+		{Vec(0, 3), false},
+		{Vec(-1, 1), false},
+	}
+	for _, c := range cases {
+		got := level.At(c.Offset)
+		if c.Found != (got != nil) || level.Contains(c.Offset) != c.Found {
+			t.Errorf("At(%v) gave incorrect bounds", c.Offset)
+		} else if got != nil && got.Offset != c.Offset {
+			t.Errorf("At(%v) gave Tile at %v", c.Offset, got.Offset)
+		}
+	}
+}
+
+func TestLevel_Region(t *testing.T) {
+	level := NewLevel(4, 3, NewTile)
+
+	var got []Vector
+	for tile := range level.Region(Vec(-1, 1), Vec(2, 5)) {
+		got = append(got, tile.Offset)
+	}
+	want := []Vector{{0, 1}, {1, 1}, {0, 2}, {1, 2}}
+	if len(got) != len(want) {
+		t.Fatalf("Region gave %v instead of %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Region gave %v instead of %v", got, want)
+		}
+	}
+
+	n := 0
+	for range level.All() {
+		n++
+	}
+	if n != 12 {
+		t.Errorf("All gave %d Tile instead of 12", n)
+	}
+}
+
+func TestTile_Neighbor(t *testing.T) {
+	level := NewLevel(3, 3, NewTile)
+	center, corner := level.At(Vec(1, 1)), level.At(Vec(0, 0))
+
+	for _, dir := range CompassDirs {
+		if got, ok := center.Neighbor(dir); !ok || got != level.At(center.Offset.Add(dir)) {
+			t.Errorf("Neighbor(%v) gave incorrect Tile", dir)
+		}
+	}
+	if _, ok := corner.Neighbor(Vec(-1, 0)); ok {
+		t.Error("Neighbor gave Tile outside the Level")
+	}
+	if _, ok := corner.Neighbor(Vec(2, 2)); ok {
+		t.Error("Neighbor gave non-compass Tile without a Link")
+	}
+
+	stairs := NewTile(Vec(0, 0))
+	corner.Link(Vec(1, 0), stairs)
+	corner.Link(Vec(0, 5), stairs)
+	if got, _ := corner.Neighbor(Vec(1, 0)); got != stairs {
+		t.Error("Neighbor ignored explicit Link")
+	}
+
+	var dirs []Vector
+	for dir := range corner.Neighbors() {
+		dirs = append(dirs, dir)
+	}
+	want := []Vector{{0, 1}, {1, 0}, {1, 1}, {0, 5}}
+	if len(dirs) != len(want) {
+		t.Fatalf("Neighbors gave %v instead of %v", dirs, want)
+	}
+	for i := range want {
+		if dirs[i] != want[i] {
+			t.Fatalf("Neighbors gave %v instead of %v", dirs, want)
+		}
+	}
+}
+
+func TestLevelWidget(t *testing.T) {
+	level := NewLevel(20, 10, NewTile)
+	level.At(Vec(5, 3)).Face = Ch('#')
+
+	w := NewLevelCamera(Vec(1, 1), Vec(4, 3), level, nil)
+	w.Focus = Vec(6, 3)
+	canvas := make(MockCanvas)
+	w.Draw(canvas)
+
+	if got := canvas[Vec(2, 2)]; got.Ch != '#' {
+		t.Errorf("LevelCamera drew %q instead of '#'", got.Ch)
+	}
+	if len(canvas) != 12 {
+		t.Errorf("LevelCamera drew %d Tile instead of 12", len(canvas))
+	}
+}
@@ -0,0 +1,99 @@
+package hjkl
+
+// Death is an Event sent to a Mob as it dies, before it is removed from play,
+// so that Component can react, e.g., by leaving a corpse, dropping items or
+// awarding experience to the Killer. Killer is nil if nothing killed the Mob
+// directly. Once the Mob is removed, the Death is also published to the Level
+// the Mob was on.
+type Death struct {
+	Mob    *Mob
+	Killer *Mob
+}
+
+// Remove is an Event sent to a Mob to remove it from play. The Mob vacates its
+// Tile, leaving it with a nil Pos.
+type Remove struct{}
+
+// Kill kills a Mob by sending it Death and then Remove. If the Mob was on a
+// Level, it is also removed from the Mobs of the Level, which calls OnRemove
+// so that the Mob can leave any clock. The Death is published last, purely so
+// the UI can report it.
+func Kill(m, killer *Mob) {
+	var level *Level
+	if m.Pos != nil {
+		level = m.Pos.level
+	}
+	death := &Death{m, killer}
+	m.Handle(death)
+	m.Handle(&Remove{})
+	if level != nil {
+		level.Mobs.Remove(m)
+	}
+	level.Publish(death)
+}
+
+// Roster is a registry of the live Mob in a level, in the order they were
+// added. Any functions in OnRemove are called with each Mob removed from the
+// Roster, which is useful for cleanup such as removing the Mob from a clock.
+type Roster struct {
+	mobs     []*Mob
+	live     map[*Mob]struct{}
+	OnRemove []func(*Mob)
+}
+
+// NewRoster creates an empty Roster.
+func NewRoster() *Roster {
+	return &Roster{live: make(map[*Mob]struct{})}
+}
+
+// Add adds a Mob to the Roster, if it is not already present.
+func (r *Roster) Add(m *Mob) {
+	if _, ok := r.live[m]; ok {
+		return
+	}
+	r.live[m] = struct{}{}
+	r.mobs = append(r.mobs, m)
+}
+
+// Spawn places a Mob on a Tile and adds it to the Roster.
+func (r *Roster) Spawn(m *Mob, t *Tile) {
+	PlaceMob(m, t)
+	r.Add(m)
+}
+
+// Remove removes a Mob from the Roster and calls OnRemove. If the Mob is still
+// on a Tile, it is sent Remove so that it leaves play.
+func (r *Roster) Remove(m *Mob) {
+	if _, ok := r.live[m]; !ok {
+		return
+	}
+	if m.Pos != nil {
+		m.Handle(&Remove{})
+	}
+	delete(r.live, m)
+	for i, x := range r.mobs {
+		if x == m {
+			r.mobs = append(r.mobs[:i], r.mobs[i+1:]...)
+			break
+		}
+	}
+	for _, f := range r.OnRemove {
+		f(m)
+	}
+}
+
+// Contains determines whether the Mob is in the Roster.
+func (r *Roster) Contains(m *Mob) bool {
+	_, ok := r.live[m]
+	return ok
+}
+
+// Mobs gets a copy of the Mob in the Roster, in the order they were added.
+func (r *Roster) Mobs() []*Mob {
+	return append([]*Mob(nil), r.mobs...)
+}
+
+// Len gets the number of Mob in the Roster.
+func (r *Roster) Len() int {
+	return len(r.mobs)
+}
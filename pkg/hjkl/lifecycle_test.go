@@ -0,0 +1,69 @@
+package hjkl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKill(t *testing.T) {
+	level := NewLevel(3, 3, NewTile)
+	tiles, roster := level.Tiles, level.Mobs
+	var removed []*Mob
+	roster.OnRemove = append(roster.OnRemove, func(m *Mob) {
+		removed = append(removed, m)
+	})
+	hero, rat, bat := NewMob(Ch('@')), NewMob(Ch('r')), NewMob(Ch('b'))
+	roster.Spawn(hero, tiles[4])
+	roster.Spawn(rat, tiles[5])
+	roster.Spawn(bat, tiles[0])
+	roster.Add(rat)
+
+	var published []Event
+	level.Events = SinkFunc(func(v Event) {
+		if roster.Contains(rat) {
+			t.Error("Death was published before the Roster forgot the Mob")
+		}
+		published = append(published, v)
+	})
+
+	var deaths []*Death
+	rat.Components.Add(Handler(func(m *Mob, v *Death) {
+		if m.Pos == nil {
+			t.Error("Death was sent after Remove")
+		}
+		m.Pos.Face = Ch('%')
+		deaths = append(deaths, v)
+	}))
+
+	Kill(rat, hero)
+	if want := []*Death{{rat, hero}}; !reflect.DeepEqual(deaths, want) {
+		t.Errorf("Kill sent Death %v", deaths)
+	}
+	if rat.Pos != nil || tiles[5].Occupant != nil || tiles[5].Face != Ch('%') {
+		t.Error("Kill did not remove the Mob from play")
+	}
+	if want := []Event{&Death{rat, hero}}; !reflect.DeepEqual(published, want) {
+		t.Errorf("Kill published %v", published)
+	}
+	if roster.Contains(rat) || !reflect.DeepEqual(removed, []*Mob{rat}) {
+		t.Error("Kill did not remove the Mob from the Roster")
+	}
+	if want := []*Mob{hero, bat}; !reflect.DeepEqual(roster.Mobs(), want) || roster.Len() != 2 {
+		t.Errorf("Roster.Mobs() = %v", roster.Mobs())
+	}
+
+	roster.Remove(bat)
+	roster.Remove(bat)
+	if bat.Pos != nil || tiles[0].Occupant != nil || len(removed) != 2 {
+		t.Error("Roster.Remove did not remove the Mob exactly once")
+	}
+}
+
+func TestKill_NoLevel(t *testing.T) {
+	rat := NewMob(Ch('r'))
+	PlaceMob(rat, NewTile(Vec(0, 0)))
+	Kill(rat, nil)
+	if rat.Pos != nil {
+		t.Error("Kill did not remove the Mob from play")
+	}
+}
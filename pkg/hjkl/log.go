@@ -12,6 +12,12 @@ type NameQuery struct {
 	Field[string]
 }
 
+// ReflexiveQuery is an Event querying an Entity for its reflexive pronoun,
+// such as "herself". Log uses it when an Entity is both subject and object.
+type ReflexiveQuery struct {
+	Field[string]
+}
+
 // Log applies a formatting language to create a log message.
 //
 // The format specifiers include the following:
@@ -68,7 +74,7 @@ func Log(s string, args ...any) string {
 				return conjugate(argstr, subjectNP)
 			case "%o":
 				if arg == subject {
-					return getReflexivePronoun(subjectNP)
+					return getReflexivePronoun(arg, subjectNP)
 				}
 				return getNounPhrase(argstr)
 			case "%x":
@@ -189,13 +195,19 @@ func conjugate(verb, subject string) string {
 	}
 }
 
-// getReflexivePronoun gets the reflexive pronoun for a noun.
-func getReflexivePronoun(noun string) string {
+// getReflexivePronoun gets the reflexive pronoun for a noun, preferring the
+// answer to ReflexiveQuery for an Entity unless in first or second person.
+func getReflexivePronoun(arg any, noun string) string {
 	if noun == "I" {
 		return "myself"
 	} else if noun == "you" {
 		return "yourself"
-	} else if isProper(noun) {
+	} else if m, ok := arg.(Entity); ok {
+		if pronoun := Get(m, &ReflexiveQuery{}); pronoun != "" {
+			return pronoun
+		}
+	}
+	if isProper(noun) {
 		return "themself"
 	}
 	return "itself"
@@ -22,6 +22,12 @@ func TestLog(t *testing.T) {
 	goblin.Components = append(goblin.Components, Handler(func(m *Mob, v *NameQuery) {
 		v.Value = "goblin"
 	}))
+	queen := &Mob{}
+	queen.Components = append(queen.Components, Handler(func(m *Mob, v *NameQuery) {
+		v.Value = "Queen Mab"
+	}), Handler(func(m *Mob, v *ReflexiveQuery) {
+		v.Value = "herself"
+	}))
 
 	cases := []struct {
 		Fmt  string
@@ -48,6 +54,8 @@ func TestLog(t *testing.T) {
 		{"%s %v %o", []any{you, "heal", you}, "You heal yourself."},
 		{"%s %v %o", []any{Grog, "heal", Grog}, "Grog heals themself."},
 		{"%s %v %o", []any{goblin, "lick", goblin}, "The goblin licks itself."},
+		{"%s %v %o", []any{queen, "crown", queen}, "Queen Mab crowns herself."},
+		{"%s %v %o", []any{queen, "crown", goblin}, "Queen Mab crowns the goblin."},
 		{"%s %v %o!", []any{I, "hit", orc}, "I hit the orc!"},
 		{"%s %v %o.", []any{I, "hit", orc}, "I hit the orc."},
 		{"%s %v %o", []any{I, "hit"}, "I hit %!o(MISSING)."},
@@ -0,0 +1,170 @@
+package hjkl
+
+import "sort"
+
+// Describe describes what an observer sees at a Tile, naming the Tile and its
+// Occupant using NameQuery and Log. A Tile or Occupant without a name is left
+// out of the description.
+func Describe(t *Tile) string {
+	tile := Get(t, &NameQuery{})
+	var occupant string
+	if t.Occupant != nil {
+		occupant = Get(t.Occupant, &NameQuery{})
+	}
+	switch {
+	case occupant != "" && tile != "":
+		return Log("%s <see> %o on %o", "you", occupant, tile)
+	case occupant != "":
+		return Log("%s <see> %o", "you", occupant)
+	case tile != "":
+		return Log("%s <see> %o", "you", tile)
+	default:
+		return Log("%s <see> nothing of interest", "you")
+	}
+}
+
+// LookMode is a Mode which moves a cursor over the Tile of a CameraWidget to
+// inspect or target them. The cursor moves with KeyDir or the mouse, but never
+// leaves the CameraWidget. Tab cycles the cursor through visible Occupant,
+// nearest first. Pressing Enter selects the Tile under the cursor, while Esc
+// cancels. Either way the LookMode pops itself and gives the Tile to Done,
+// along with whether a Tile was selected.
+//
+// While active, a description of the Tile under the cursor is drawn in the
+// Window, respecting the View of the CameraWidget.
+type LookMode struct {
+	Window
+	Camera *CameraWidget
+	Origin *Tile
+	Cursor *Tile
+	Done   func(*Tile, bool) error
+}
+
+// NewLookMode creates a LookMode with the cursor at the origin Tile.
+func NewLookMode(pos, size Vector, camera *CameraWidget, origin *Tile, done func(*Tile, bool) error) *LookMode {
+	return &LookMode{Window{pos, size}, camera, origin, origin, done}
+}
+
+// Update moves the cursor until a Tile is selected or the look cancelled.
+func (m *LookMode) Update(s *Stack, ins []Input) error {
+	for _, in := range ins {
+		switch in.Kind {
+		case InputMouse:
+			if in.Mouse == MouseLeft {
+				m.moveTo(m.Camera.ToMap(in.Pos))
+			}
+		case InputKey:
+			switch in.Key {
+			case KeyEnter:
+				s.Pop()
+				return m.Done(m.Cursor, true)
+			case KeyEsc:
+				s.Pop()
+				return m.Done(nil, false)
+			case KeyTab:
+				m.cycle()
+			default:
+				if dir, ok := KeyDir(in.Key); ok {
+					if dst, ok := m.Cursor.Neighbor(dir); ok && m.onScreen(dst, m.Camera.Origin()) {
+						m.Cursor = dst
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// onScreen determines whether the Tile is drawn within the CameraWidget,
+// given the current origin of the CameraWidget.
+func (m *LookMode) onScreen(t *Tile, origin Vector) bool {
+	v := t.Offset.Sub(origin)
+	return 0 <= v.X && v.X < m.Camera.Size.X && 0 <= v.Y && v.Y < m.Camera.Size.Y
+}
+
+// visible determines whether the Tile is visible in the CameraWidget View.
+func (m *LookMode) visible(t *Tile) bool {
+	return m.Camera.View == nil || m.Camera.View.Visible(t)
+}
+
+// moveTo moves the cursor to the Tile with the given offset, if it is
+// on screen.
+func (m *LookMode) moveTo(offset Vector) {
+	origin := m.Camera.Origin()
+	for t := range m.Camera.window(origin) {
+		if t.Offset == offset && m.onScreen(t, origin) {
+			m.Cursor = t
+			return
+		}
+	}
+}
+
+// Targets gets the on screen Tile with a visible Occupant, other than the
+// origin, sorted by distance from the origin.
+func (m *LookMode) Targets() []*Tile {
+	var targets []*Tile
+	origin := m.Camera.Origin()
+	for t := range m.Camera.window(origin) {
+		if t.Occupant != nil && t != m.Origin && m.visible(t) && m.onScreen(t, origin) {
+			targets = append(targets, t)
+		}
+	}
+	dist := func(t *Tile) int {
+		d := t.Offset.Sub(m.Origin.Offset)
+		return max(d.X, -d.X, d.Y, -d.Y)
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		a, b := targets[i], targets[j]
+		if da, db := dist(a), dist(b); da != db {
+			return da < db
+		}
+		if a.Offset.Y != b.Offset.Y {
+			return a.Offset.Y < b.Offset.Y
+		}
+		return a.Offset.X < b.Offset.X
+	})
+	return targets
+}
+
+// cycle moves the cursor to the next target after the cursor.
+func (m *LookMode) cycle() {
+	targets := m.Targets()
+	if len(targets) == 0 {
+		return
+	}
+	next := 0
+	for i, t := range targets {
+		if t == m.Cursor {
+			next = (i + 1) % len(targets)
+			break
+		}
+	}
+	m.Cursor = targets[next]
+}
+
+// Description describes the Tile under the cursor. Tile which are not visible
+// are only described if they are remembered.
+func (m *LookMode) Description() string {
+	if m.visible(m.Cursor) {
+		return Describe(m.Cursor)
+	}
+	if _, ok := m.Camera.View.Remembered(m.Cursor); ok {
+		if name := Get(m.Cursor, &NameQuery{}); name != "" {
+			return Log("%s <remember> %o", "you", name)
+		}
+		return Log("%s <remember> nothing of interest", "you")
+	}
+	return Log("%s cannot see there", "you")
+}
+
+// Draw highlights the cursor and draws the description.
+func (m *LookMode) Draw(c Canvas) {
+	g := Ch(' ')
+	if m.visible(m.Cursor) {
+		g = Get(m.Cursor, &Face{})
+	} else if remembered, ok := m.Camera.View.Remembered(m.Cursor); ok {
+		g = remembered
+	}
+	c.Blit(m.Camera.ToScreen(m.Cursor.Offset), Glyph{Ch: g.Ch, Fg: ColorBlack, Bg: ColorWhite})
+	drawPrompt(c, &m.Window, m.Description())
+}
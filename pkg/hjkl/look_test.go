@@ -0,0 +1,115 @@
+package hjkl
+
+import (
+	"reflect"
+	"testing"
+)
+
+// NamedMob creates a Mob which answers NameQuery with the given name.
+func NamedMob(face rune, name string) *Mob {
+	m := NewMob(Ch(face))
+	m.Components.Add(Handler(func(_ *Mob, v *NameQuery) {
+		v.Value = name
+	}))
+	return m
+}
+
+func TestDescribe(t *testing.T) {
+	tile := NewTile(Vec(0, 0))
+	if got := Describe(tile); got != "You see nothing of interest." {
+		t.Errorf("Describe gave %q", got)
+	}
+	PlaceMob(NamedMob('r', "rat"), tile)
+	if got := Describe(tile); got != "You see the rat." {
+		t.Errorf("Describe gave %q", got)
+	}
+	tile.Components.Add(Handler(func(_ *Tile, v *NameQuery) {
+		v.Value = "grass"
+	}))
+	if got := Describe(tile); got != "You see the rat on the grass." {
+		t.Errorf("Describe gave %q", got)
+	}
+	tile.Occupant = nil
+	if got := Describe(tile); got != "You see the grass." {
+		t.Errorf("Describe gave %q", got)
+	}
+}
+
+func TestLookMode(t *testing.T) {
+	tiles := NewTestGrid(10, 10)
+	at := func(x, y int) *Tile { return tiles[y*10+x] }
+	hero := NamedMob('@', "you")
+	PlaceMob(hero, at(2, 2))
+	PlaceMob(NamedMob('r', "rat"), at(5, 2))
+	PlaceMob(NamedMob('D', "Gorp"), at(3, 4))
+	PlaceMob(NamedMob('b', "bat"), at(9, 9))
+	camera := NewCameraWidget(Vec(0, 1), Vec(8, 8), tiles, hero)
+
+	var selected *Tile
+	var ok bool
+	s := NewStack(&MockMode{Log: new([]string)})
+	m := NewLookMode(Vec(0, 0), Vec(30, 1), camera, hero.Pos, func(t *Tile, given bool) error {
+		selected, ok = t, given
+		return nil
+	})
+	s.Push(m)
+
+	// The bat is off screen, so only Gorp and the rat are targets.
+	if got, want := m.Targets(), []*Tile{at(3, 4), at(5, 2)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LookMode.Targets() = %v, want %v", got, want)
+	}
+
+	cases := []struct {
+		Input       Input
+		Cursor      *Tile
+		Description string
+	}{
+		{KeyPress('l'), at(3, 2), "You see nothing of interest."},
+		{KeyPress(KeyTab), at(3, 4), "You see Gorp."},
+		{KeyPress(KeyTab), at(5, 2), "You see the rat."},
+		{KeyPress(KeyTab), at(3, 4), "You see Gorp."},
+		{KeyPress('n'), at(4, 5), "You see nothing of interest."},
+		{Input{Kind: InputMouse, Mouse: MouseLeft, Pos: Vec(2, 3)}, at(2, 2), "You see yourself."},
+		{Input{Kind: InputMouse, Mouse: MouseLeft, Pos: Vec(9, 9)}, at(2, 2), "You see yourself."},
+		{KeyPress('y'), at(1, 1), "You see nothing of interest."},
+		{KeyPress('y'), at(0, 0), "You see nothing of interest."},
+		{KeyPress('y'), at(0, 0), "You see nothing of interest."},
+	}
+	for _, c := range cases {
+		s.Update([]Input{c.Input})
+		if m.Cursor != c.Cursor {
+			t.Errorf("LookMode moved cursor to %v instead of %v", m.Cursor.Offset, c.Cursor.Offset)
+		}
+		if got := m.Description(); got != c.Description {
+			t.Errorf("LookMode.Description() = %q, want %q", got, c.Description)
+		}
+	}
+
+	canvas := make(MockCanvas)
+	m.Draw(canvas)
+	if canvas[Vec(0, 1)] != (Glyph{'#', ColorBlack, ColorWhite}) {
+		t.Error("LookMode did not highlight the cursor", canvas[Vec(0, 1)])
+	}
+
+	s.Update([]Input{KeyPress(KeyEnter)})
+	if selected != at(0, 0) || !ok || s.Len() != 1 {
+		t.Error("LookMode did not select the cursor Tile")
+	}
+}
+
+func TestLookMode_View(t *testing.T) {
+	tiles := NewTestGrid(3, 1)
+	camera := NewCameraWidget(Vec(0, 0), Vec(3, 1), tiles, nil)
+	camera.View = MockView{
+		visible:    map[*Tile]bool{tiles[0]: true},
+		remembered: map[*Tile]Glyph{tiles[1]: Ch('#')},
+	}
+	m := NewLookMode(Vec(0, 0), Vec(30, 1), camera, tiles[0], nil)
+	want := []string{"You see nothing of interest.", "You remember nothing of interest.", "You cannot see there."}
+	for i, t2 := range tiles {
+		m.Cursor = t2
+		if got := m.Description(); got != want[i] {
+			t.Errorf("LookMode.Description() = %q, want %q", got, want[i])
+		}
+	}
+}
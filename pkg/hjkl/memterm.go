@@ -0,0 +1,148 @@
+package hjkl
+
+import "strings"
+
+// MemTerminal is a headless Terminal which keeps its cells in memory. Input is
+// scripted ahead of time with Send or SendInput, one batch per tick, and Run
+// terminates normally once the script runs out. This makes MemTerminal
+// suitable for integration tests and bots which must run without a TTY.
+type MemTerminal struct {
+	size   Vector
+	cells  []Glyph
+	frame  []Glyph
+	script [][]Input
+	frames int
+	done   bool
+}
+
+// NewMemTerminal creates a MemTerminal with the given size.
+func NewMemTerminal(size Vector) *MemTerminal {
+	t := &MemTerminal{}
+	t.resize(size)
+	return t
+}
+
+// resize reallocates the cell buffer and frame with the given size.
+func (t *MemTerminal) resize(size Vector) {
+	t.size = size
+	t.cells = make([]Glyph, size.X*size.Y)
+	t.frame = make([]Glyph, size.X*size.Y)
+}
+
+// Send scripts a batch of KeyPress to be delivered together on a single tick.
+// Calling Send with no keys scripts a tick with no input.
+func (t *MemTerminal) Send(keys ...Key) {
+	var inputs []Input
+	for _, k := range keys {
+		inputs = append(inputs, KeyPress(k))
+	}
+	t.script = append(t.script, inputs)
+}
+
+// SendInput scripts a batch of Input to be delivered together on a single
+// tick, which allows scripting modifiers, mouse and resize events. Scripting an
+// InputResize resizes the MemTerminal once the batch is delivered.
+func (t *MemTerminal) SendInput(inputs ...Input) {
+	t.script = append(t.script, inputs)
+}
+
+// NextTick implements TickInput by popping the next scripted batch of Input.
+func (t *MemTerminal) NextTick() ([]Input, bool) {
+	if len(t.script) == 0 {
+		return nil, false
+	}
+	inputs := t.script[0]
+	t.script = t.script[1:]
+	for _, in := range inputs {
+		if in.Kind == InputResize {
+			t.resize(in.Pos)
+		}
+	}
+	return inputs, true
+}
+
+// Blit places a Glyph into the cell buffer, ignoring out of bounds Vector.
+func (t *MemTerminal) Blit(v Vector, g Glyph) {
+	if 0 <= v.X && v.X < t.size.X && 0 <= v.Y && v.Y < t.size.Y {
+		t.cells[v.Y*t.size.X+v.X] = g
+	}
+}
+
+// Init resets the MemTerminal so it can be reused by Run.
+func (t *MemTerminal) Init() error {
+	t.done = false
+	return nil
+}
+
+// Input returns nil, since a MemTerminal uses NextTick for input.
+func (*MemTerminal) Input() chan Input {
+	return nil
+}
+
+// Size gets the current size of the MemTerminal.
+func (t *MemTerminal) Size() Vector {
+	return t.size
+}
+
+// Done marks the MemTerminal as done.
+func (t *MemTerminal) Done() {
+	t.done = true
+}
+
+// Clear clears the cell buffer.
+func (t *MemTerminal) Clear() {
+	clear(t.cells)
+}
+
+// Flush copies the cell buffer to the frame.
+func (t *MemTerminal) Flush() error {
+	copy(t.frame, t.cells)
+	t.frames++
+	return nil
+}
+
+// IsDone returns true if Done has been called since the last Init.
+func (t *MemTerminal) IsDone() bool {
+	return t.done
+}
+
+// Frames returns the number of times Flush has been called.
+func (t *MemTerminal) Frames() int {
+	return t.frames
+}
+
+// At gets the Glyph at a position in the last flushed frame.
+func (t *MemTerminal) At(v Vector) Glyph {
+	if 0 <= v.X && v.X < t.size.X && 0 <= v.Y && v.Y < t.size.Y {
+		return t.frame[v.Y*t.size.X+v.X]
+	}
+	return Glyph{}
+}
+
+// Grid gets a copy of the last flushed frame, indexed by row then column.
+func (t *MemTerminal) Grid() [][]Glyph {
+	grid := make([][]Glyph, t.size.Y)
+	for y := range grid {
+		grid[y] = append([]Glyph(nil), t.frame[y*t.size.X:(y+1)*t.size.X]...)
+	}
+	return grid
+}
+
+// String gets the characters of the last flushed frame, with one line per row.
+// Empty cells are given as spaces so each line is the full width.
+func (t *MemTerminal) String() string {
+	var b strings.Builder
+	for y := 0; y < t.size.Y; y++ {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		for x := 0; x < t.size.X; x++ {
+			ch := t.frame[y*t.size.X+x].Ch
+			if ch == 0 {
+				ch = ' '
+			}
+			b.WriteRune(ch)
+		}
+	}
+	return b.String()
+}
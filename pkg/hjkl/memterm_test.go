@@ -0,0 +1,55 @@
+package hjkl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemTerminal(t *testing.T) {
+	term := NewMemTerminal(Vec(4, 2))
+	term.Send('l')
+	term.Send()
+	term.Send('j', 'h')
+
+	pos := Vec(0, 0)
+	var batches [][]Input
+	game := &MockGame{
+		UpdateFn: func(ins []Input) error {
+			batches = append(batches, append([]Input(nil), ins...))
+			for _, in := range ins {
+				pos = pos.Add(VIKeyDirs[in.Key])
+			}
+			return nil
+		},
+		DrawFn: func(c Canvas) {
+			c.Blit(pos, Ch('@'))
+			c.Blit(Vec(3, 0), ChFg('#', ColorRed))
+			c.Blit(Vec(4, 0), Ch('X'))
+		},
+	}
+
+	if err := Run(game, WithTerm(term), WithTPS(0)); err != nil {
+		t.Fatal("Run with MemTerminal gave error", err)
+	}
+
+	want := [][]Input{{KeyPress('l')}, nil, {KeyPress('j'), KeyPress('h')}}
+	if !reflect.DeepEqual(batches, want) {
+		t.Errorf("MemTerminal gave incorrect input %v", batches)
+	}
+	if !term.IsDone() {
+		t.Error("MemTerminal was not Done after Run")
+	}
+	if term.Frames() != 3 {
+		t.Errorf("MemTerminal got %d frames", term.Frames())
+	}
+	if want := "   #\n@   "; term.String() != want {
+		t.Errorf("MemTerminal.String() = %q", term.String())
+	}
+	if term.At(Vec(3, 0)) != ChFg('#', ColorRed) {
+		t.Error("MemTerminal.At gave incorrect Glyph")
+	}
+	grid := term.Grid()
+	if len(grid) != 2 || len(grid[0]) != 4 || grid[1][0] != Ch('@') {
+		t.Error("MemTerminal.Grid gave incorrect grid")
+	}
+}
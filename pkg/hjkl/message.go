@@ -0,0 +1,153 @@
+package hjkl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Message is a single entry in a MessageLog.
+type Message struct {
+	Turn  int
+	Text  string
+	Count int
+}
+
+// String gets the text of the Message, noting how many times it repeated.
+func (m Message) String() string {
+	if m.Count > 1 {
+		return fmt.Sprintf("%s (x%d)", m.Text, m.Count)
+	}
+	return m.Text
+}
+
+// MessageLog accumulates Message for display. Consecutive repeats of the same
+// text are collapsed into a single Message with a Count.
+type MessageLog struct {
+	Messages []Message
+
+	// Limit is the maximum number of Message retained as history, with the
+	// oldest Message discarded first. A Limit of zero or less is unlimited.
+	Limit int
+}
+
+// NewMessageLog creates an empty MessageLog with the given Limit.
+func NewMessageLog(limit int) *MessageLog {
+	return &MessageLog{Limit: limit}
+}
+
+// Add adds text to the MessageLog with the given turn stamp. If the text
+// repeats the previous Message, that Message is collapsed instead, taking the
+// new turn stamp.
+func (l *MessageLog) Add(turn int, text string) {
+	if n := len(l.Messages); n > 0 && l.Messages[n-1].Text == text {
+		l.Messages[n-1].Turn = turn
+		l.Messages[n-1].Count++
+		return
+	}
+	l.Messages = append(l.Messages, Message{turn, text, 1})
+	if l.Limit > 0 && len(l.Messages) > l.Limit {
+		l.Messages = append(l.Messages[:0], l.Messages[len(l.Messages)-l.Limit:]...)
+	}
+}
+
+// Log formats a message with Log and adds it with the given turn stamp.
+func (l *MessageLog) Log(turn int, s string, args ...any) {
+	l.Add(turn, Log(s, args...))
+}
+
+// Turn gets the turn stamp of the latest Message, or 0 if there are none.
+func (l *MessageLog) Turn() int {
+	if len(l.Messages) == 0 {
+		return 0
+	}
+	return l.Messages[len(l.Messages)-1].Turn
+}
+
+// Wrap word-wraps text to lines of at most width characters. Words longer than
+// the width are broken across lines.
+func Wrap(s string, width int) []string {
+	if width <= 0 {
+		return nil
+	}
+
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(s) {
+		w := []rune(word)
+		if len(line) > 0 && len(line)+1+len(w) > width {
+			lines = append(lines, string(line))
+			line = line[:0]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		for len(line)+len(w) > width {
+			n := width - len(line)
+			lines = append(lines, string(append(line, w[:n]...)))
+			line, w = line[:0], w[n:]
+		}
+		line = append(line, w...)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+// MessageWidget is a Widget which draws the latest lines of a MessageLog,
+// word-wrapped to the Window width. Message from the latest turn are drawn
+// normally, while older Message are dimmed.
+type MessageWidget struct {
+	Window
+	Log *MessageLog
+
+	// Scroll is the number of lines scrolled back into the history.
+	Scroll int
+}
+
+// NewMessageWidget creates a MessageWidget which draws the given MessageLog.
+func NewMessageWidget(pos, size Vector, log *MessageLog) *MessageWidget {
+	return &MessageWidget{Window: Window{pos, size}, Log: log}
+}
+
+// messageLine is a single wrapped line of a Message.
+type messageLine struct {
+	text   string
+	latest bool
+}
+
+// lines gets every wrapped line of the MessageLog, oldest first.
+func (w *MessageWidget) lines() []messageLine {
+	var lines []messageLine
+	turn := w.Log.Turn()
+	for _, m := range w.Log.Messages {
+		for _, text := range Wrap(m.String(), w.Size.X) {
+			lines = append(lines, messageLine{text, m.Turn == turn})
+		}
+	}
+	return lines
+}
+
+// ScrollBy scrolls back into the history by the given number of lines, or
+// forward if negative. Scrolling stops at the oldest and newest lines.
+func (w *MessageWidget) ScrollBy(n int) {
+	limit := max(0, len(w.lines())-w.Size.Y)
+	w.Scroll = max(0, min(limit, w.Scroll+n))
+}
+
+// Draw draws the lines which fit in the Window, ending with the newest line
+// unless scrolled back.
+func (w *MessageWidget) Draw(c Canvas) {
+	lines := w.lines()
+	end := max(0, len(lines)-max(0, w.Scroll))
+	start := max(0, end-w.Size.Y)
+	for y, line := range lines[start:end] {
+		for x, ch := range []rune(line.text) {
+			g := Ch(ch)
+			if !line.latest {
+				g = Dim(g)
+			}
+			w.RelBlit(c, Vec(x, y), g)
+		}
+	}
+}
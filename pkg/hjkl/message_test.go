@@ -0,0 +1,87 @@
+package hjkl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMessageLog(t *testing.T) {
+	log := NewMessageLog(3)
+	log.Log(1, "%s <hit> %o", "you", "rat")
+	log.Log(1, "%s <hit> %o", "you", "rat")
+	log.Log(2, "%s <hit> %o", "you", "rat")
+	log.Add(2, "The rat bites you.")
+	log.Add(3, "You hit the rat.")
+	log.Add(4, "The rat dies.")
+
+	want := []Message{
+		{2, "The rat bites you.", 1},
+		{3, "You hit the rat.", 1},
+		{4, "The rat dies.", 1},
+	}
+	if !reflect.DeepEqual(log.Messages, want) {
+		t.Errorf("MessageLog gave %v", log.Messages)
+	}
+
+	log = NewMessageLog(0)
+	for i := 0; i < 3; i++ {
+		log.Add(i, "You hit the rat.")
+	}
+	if len(log.Messages) != 1 || log.Messages[0].String() != "You hit the rat. (x3)" {
+		t.Errorf("MessageLog did not collapse repeats: %v", log.Messages)
+	}
+	if log.Turn() != 2 {
+		t.Errorf("MessageLog.Turn() = %d", log.Turn())
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cases := []struct {
+		Text  string
+		Width int
+		Want  []string
+	}{
+		{"The bear hits you.", 20, []string{"The bear hits you."}},
+		{"The bear hits you.", 9, []string{"The bear", "hits you."}},
+		{"The bear hits you.", 8, []string{"The bear", "hits", "you."}},
+		{"You hit the saber-tooth.", 6, []string{"You", "hit", "the", "saber-", "tooth."}},
+		{"  spaced   out  ", 20, []string{"spaced out"}},
+		{"", 10, nil},
+		{"anything", 0, nil},
+	}
+	for _, c := range cases {
+		if got := Wrap(c.Text, c.Width); !reflect.DeepEqual(got, c.Want) {
+			t.Errorf("Wrap(%q, %d) = %q", c.Text, c.Width, got)
+		}
+	}
+}
+
+func TestMessageWidget(t *testing.T) {
+	log := NewMessageLog(0)
+	log.Add(1, "You hit the rat.")
+	log.Add(2, "The rat bites you.")
+	log.Add(2, "The rat bites you.")
+	w := NewMessageWidget(Vec(1, 1), Vec(10, 2), log)
+
+	c := make(MockCanvas)
+	w.Draw(c)
+	// The lines are "You hit", "the rat.", "The rat", "bites you." and "(x2)".
+	if c[Vec(1, 1)] != Ch('b') || c[Vec(1, 2)] != Ch('(') || len(c) != 14 {
+		t.Error("MessageWidget.Draw produced incorrect buffer", c)
+	}
+
+	w.ScrollBy(100)
+	if w.Scroll != 3 {
+		t.Errorf("MessageWidget.ScrollBy gave Scroll %d", w.Scroll)
+	}
+	c = make(MockCanvas)
+	w.Draw(c)
+	if c[Vec(1, 1)] != Dim(Ch('Y')) || c[Vec(1, 2)] != Dim(Ch('t')) {
+		t.Error("MessageWidget.Draw produced incorrect scrolled buffer", c)
+	}
+
+	w.ScrollBy(-100)
+	if w.Scroll != 0 {
+		t.Errorf("MessageWidget.ScrollBy gave Scroll %d", w.Scroll)
+	}
+}
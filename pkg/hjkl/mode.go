@@ -0,0 +1,225 @@
+package hjkl
+
+// Mode is a layer of a Stack, such as the main game view, a menu or a prompt.
+// Mode are like Game, except that Update is also given the Stack so the Mode
+// can push other Mode or pop itself.
+type Mode interface {
+	Update(s *Stack, ins []Input) error
+	Draw(Canvas)
+}
+
+// Stack is a Game which runs a stack of Mode. Only the top Mode gets input,
+// but every Mode is drawn from the bottom up, so pushed Mode draw over those
+// below them. Once the Stack is empty, Update returns Termination.
+type Stack struct {
+	modes []Mode
+	size  Vector
+}
+
+// NewStack creates a Stack with the given base Mode.
+func NewStack(base Mode) *Stack {
+	return &Stack{modes: []Mode{base}}
+}
+
+// Push pushes a Mode onto the Stack so that it gets input.
+func (s *Stack) Push(m Mode) {
+	s.modes = append(s.modes, m)
+	if r, ok := m.(Resizer); ok && s.size != (Vector{}) {
+		r.Resize(s.size)
+	}
+}
+
+// Pop removes the top Mode from the Stack.
+func (s *Stack) Pop() {
+	if len(s.modes) > 0 {
+		s.modes[len(s.modes)-1] = nil
+		s.modes = s.modes[:len(s.modes)-1]
+	}
+}
+
+// Top gets the top Mode of the Stack, or nil if the Stack is empty.
+func (s *Stack) Top() Mode {
+	if len(s.modes) == 0 {
+		return nil
+	}
+	return s.modes[len(s.modes)-1]
+}
+
+// Len gets the number of Mode in the Stack.
+func (s *Stack) Len() int {
+	return len(s.modes)
+}
+
+// Size gets the size last given to Resize.
+func (s *Stack) Size() Vector {
+	return s.size
+}
+
+// Update gives the Input to the top Mode.
+func (s *Stack) Update(ins []Input) error {
+	top := s.Top()
+	if top == nil {
+		return Termination
+	}
+	return top.Update(s, ins)
+}
+
+// Draw draws each Mode from the bottom up.
+func (s *Stack) Draw(c Canvas) {
+	for _, m := range s.modes {
+		m.Draw(c)
+	}
+}
+
+// Resize resizes each Mode which implements Resizer. Mode pushed later are
+// resized as they are pushed.
+func (s *Stack) Resize(size Vector) {
+	s.size = size
+	for _, m := range s.modes {
+		if r, ok := m.(Resizer); ok {
+			r.Resize(size)
+		}
+	}
+}
+
+// drawPrompt draws a single line prompt in a Window, clearing the rest of the
+// line so the prompt is legible over whatever is below it.
+func drawPrompt(c Canvas, w *Window, prompt string) {
+	text := []rune(prompt)
+	for x := 0; x < w.Size.X; x++ {
+		g := Ch(' ')
+		if x < len(text) {
+			g.Ch = text[x]
+		}
+		w.RelBlit(c, Vec(x, 0), g)
+	}
+}
+
+// ConfirmMode is a Mode which asks a yes or no question. Pressing 'y' answers
+// yes, while 'n' or Esc answers no. Either way the ConfirmMode pops itself and
+// gives the answer to Done.
+type ConfirmMode struct {
+	Window
+	Prompt string
+	Done   func(bool) error
+}
+
+// NewConfirmMode creates a ConfirmMode with the given prompt and callback.
+func NewConfirmMode(pos, size Vector, prompt string, done func(bool) error) *ConfirmMode {
+	return &ConfirmMode{Window{pos, size}, prompt, done}
+}
+
+// Update waits for a yes or no answer.
+func (m *ConfirmMode) Update(s *Stack, ins []Input) error {
+	for _, in := range ins {
+		if in.Kind != InputKey {
+			continue
+		}
+		switch in.Key {
+		case 'y', 'Y':
+			s.Pop()
+			return m.Done(true)
+		case 'n', 'N', KeyEsc:
+			s.Pop()
+			return m.Done(false)
+		}
+	}
+	return nil
+}
+
+// Draw draws the prompt.
+func (m *ConfirmMode) Draw(c Canvas) {
+	drawPrompt(c, &m.Window, m.Prompt+" (y/n)")
+}
+
+// DirectionMode is a Mode which asks for a direction using KeyDir. Once a
+// direction is given, DirectionMode pops itself and gives the direction to
+// Done. Pressing Esc instead cancels, giving false to Done.
+type DirectionMode struct {
+	Window
+	Prompt string
+	Done   func(Vector, bool) error
+}
+
+// NewDirectionMode creates a DirectionMode with the given prompt and callback.
+func NewDirectionMode(pos, size Vector, prompt string, done func(Vector, bool) error) *DirectionMode {
+	return &DirectionMode{Window{pos, size}, prompt, done}
+}
+
+// Update waits for a direction.
+func (m *DirectionMode) Update(s *Stack, ins []Input) error {
+	for _, in := range ins {
+		if in.Kind != InputKey {
+			continue
+		}
+		if in.Key == KeyEsc {
+			s.Pop()
+			return m.Done(Vector{}, false)
+		}
+		if dir, ok := KeyDir(in.Key); ok {
+			s.Pop()
+			return m.Done(dir, true)
+		}
+	}
+	return nil
+}
+
+// Draw draws the prompt.
+func (m *DirectionMode) Draw(c Canvas) {
+	drawPrompt(c, &m.Window, m.Prompt)
+}
+
+// MenuMode is a Mode which shows a scrollable list of items in a titled
+// panel. The selection is moved with the up and down directions of KeyDir or
+// the mouse wheel. Pressing Enter chooses the selected item, while Esc
+// cancels. Either way the MenuMode pops itself and gives the index of the
+// chosen item to Done, along with whether an item was chosen.
+type MenuMode struct {
+	*PanelWidget
+	List *ListWidget
+	Done func(int, bool) error
+}
+
+// NewMenuMode creates a MenuMode with the given title, items and callback.
+func NewMenuMode(pos, size Vector, title string, items []string, done func(int, bool) error) *MenuMode {
+	list := NewListWidget(Vector{}, Vector{}, items)
+	return &MenuMode{NewPanelWidget(pos, size, title, list), list, done}
+}
+
+// Update moves the selection until an item is chosen or the menu cancelled.
+func (m *MenuMode) Update(s *Stack, ins []Input) error {
+	for _, in := range ins {
+		switch in.Kind {
+		case InputMouse:
+			switch in.Mouse {
+			case MouseWheelUp:
+				m.List.Select(-1)
+			case MouseWheelDown:
+				m.List.Select(1)
+			}
+		case InputKey:
+			switch in.Key {
+			case KeyEnter:
+				s.Pop()
+				return m.Done(m.List.Selected, len(m.List.Items) > 0)
+			case KeyEsc:
+				s.Pop()
+				return m.Done(-1, false)
+			}
+			if dir, ok := KeyDir(in.Key); ok && dir.X == 0 {
+				m.List.Select(dir.Y)
+			}
+		}
+	}
+	return nil
+}
+
+// Draw draws the panel over whatever is below it.
+func (m *MenuMode) Draw(c Canvas) {
+	for y := 0; y < m.Size.Y; y++ {
+		for x := 0; x < m.Size.X; x++ {
+			m.RelBlit(c, Vec(x, y), Ch(' '))
+		}
+	}
+	m.PanelWidget.Draw(c)
+}
@@ -0,0 +1,142 @@
+package hjkl
+
+import (
+	"reflect"
+	"testing"
+)
+
+type MockMode struct {
+	Name     string
+	Log      *[]string
+	UpdateFn func(*Stack, []Input) error
+}
+
+func (m *MockMode) Update(s *Stack, ins []Input) error {
+	*m.Log = append(*m.Log, "Update "+m.Name)
+	if m.UpdateFn != nil {
+		return m.UpdateFn(s, ins)
+	}
+	return nil
+}
+
+func (m *MockMode) Draw(Canvas) {
+	*m.Log = append(*m.Log, "Draw "+m.Name)
+}
+
+func TestStack(t *testing.T) {
+	var log []string
+	top := &MockMode{Name: "top", Log: &log, UpdateFn: func(s *Stack, _ []Input) error {
+		s.Pop()
+		return nil
+	}}
+	base := &MockMode{Name: "base", Log: &log, UpdateFn: func(s *Stack, _ []Input) error {
+		s.Push(top)
+		return nil
+	}}
+	s := NewStack(base)
+
+	s.Update(nil)
+	s.Draw(nil)
+	if s.Len() != 2 || s.Top() != top {
+		t.Error("Stack.Push did not push Mode")
+	}
+	s.Update(nil)
+	s.Draw(nil)
+	if s.Len() != 1 || s.Top() != base {
+		t.Error("Stack.Pop did not pop Mode")
+	}
+	want := []string{"Update base", "Draw base", "Draw top", "Update top", "Draw base"}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("Stack gave %v instead of %v", log, want)
+	}
+
+	s.Pop()
+	if s.Top() != nil || s.Update(nil) != Termination {
+		t.Error("Empty Stack did not terminate")
+	}
+}
+
+func TestConfirmMode(t *testing.T) {
+	cases := []struct {
+		Keys   []Key
+		Answer bool
+		Popped bool
+	}{
+		{[]Key{'x', 'y'}, true, true},
+		{[]Key{'n'}, false, true},
+		{[]Key{KeyEsc}, false, true},
+		{[]Key{'x'}, false, false},
+	}
+	for _, c := range cases {
+		var answer, done bool
+		s := NewStack(&MockMode{Log: new([]string)})
+		s.Push(NewConfirmMode(Vec(0, 0), Vec(20, 1), "Quit?", func(yes bool) error {
+			answer, done = yes, true
+			return nil
+		}))
+		var ins []Input
+		for _, k := range c.Keys {
+			ins = append(ins, KeyPress(k))
+		}
+		if err := s.Update(ins); err != nil {
+			t.Fatal("ConfirmMode.Update gave error", err)
+		}
+		if answer != c.Answer || done != c.Popped || (s.Len() == 1) != c.Popped {
+			t.Errorf("ConfirmMode with %v gave %v, %v", c.Keys, answer, done)
+		}
+	}
+
+	canvas := make(MockCanvas)
+	NewConfirmMode(Vec(0, 0), Vec(16, 1), "Quit?", nil).Draw(canvas)
+	ExpectChars(t, canvas, []string{"Quit? (y/n)     "})
+}
+
+func TestDirectionMode(t *testing.T) {
+	var got Vector
+	var ok bool
+	s := NewStack(&MockMode{Log: new([]string)})
+	s.Push(NewDirectionMode(Vec(0, 0), Vec(20, 1), "Which way?", func(dir Vector, given bool) error {
+		got, ok = dir, given
+		return Termination
+	}))
+	if err := s.Update([]Input{KeyPress('x'), KeyPress(KeyArrowLeft)}); err != Termination {
+		t.Error("DirectionMode did not return the error from Done")
+	}
+	if got != Vec(-1, 0) || !ok || s.Len() != 1 {
+		t.Errorf("DirectionMode gave %v, %v", got, ok)
+	}
+}
+
+func TestMenuMode(t *testing.T) {
+	var chosen int
+	var ok bool
+	items := []string{"apple", "bread", "cheese", "dates"}
+	s := NewStack(&MockMode{Log: new([]string)})
+	m := NewMenuMode(Vec(0, 0), Vec(10, 4), "Eat", items, func(i int, given bool) error {
+		chosen, ok = i, given
+		return nil
+	})
+	s.Push(m)
+
+	s.Update([]Input{KeyPress('j'), KeyPress('j'), KeyPress('l'), KeyPress('j')})
+	s.Update([]Input{{Kind: InputMouse, Mouse: MouseWheelUp}})
+	c := make(MockCanvas)
+	s.Draw(c)
+	ExpectChars(t, c, []string{
+		"┌ Eat ───┐",
+		"│bread   │",
+		"│cheese  │",
+		"└────────┘",
+	})
+
+	s.Update([]Input{KeyPress(KeyEnter)})
+	if chosen != 2 || !ok || s.Len() != 1 {
+		t.Errorf("MenuMode chose %d, %v", chosen, ok)
+	}
+
+	s.Push(m)
+	s.Update([]Input{KeyPress(KeyEsc)})
+	if chosen != -1 || ok || s.Len() != 1 {
+		t.Errorf("MenuMode cancelled with %d, %v", chosen, ok)
+	}
+}
@@ -0,0 +1,219 @@
+// Package path provides pathfinding over Tile graphs.
+package path
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+)
+
+// Config stores options for pathfinding.
+type Config struct {
+	// Pass returns true if a Tile may be traversed.
+	Pass func(*hjkl.Tile) bool
+	// Cost returns the cost of stepping between two adjacent Tile. The cost
+	// must be at least 1, or else AStar may not find the shortest path.
+	Cost func(src, dst *hjkl.Tile) int
+}
+
+// DefaultConfig creates a Config with default settings.
+func DefaultConfig() *Config {
+	return &Config{
+		Pass: hjkl.OpenTile,
+		Cost: UnitCost,
+	}
+}
+
+// Option is a function which mutates a Config for pathfinding.
+type Option func(*Config)
+
+// WithPass gets an Option which sets the passability predicate of a Config.
+func WithPass(f func(*hjkl.Tile) bool) Option {
+	return func(c *Config) {
+		c.Pass = f
+	}
+}
+
+// WithCost gets an Option which sets the step cost function of a Config.
+func WithCost(f func(src, dst *hjkl.Tile) int) Option {
+	return func(c *Config) {
+		c.Cost = f
+	}
+}
+
+// newConfig applies the options to a DefaultConfig.
+func newConfig(opts []Option) *Config {
+	config := DefaultConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+	return config
+}
+
+// UnitCost is a cost function in which every step costs 1.
+func UnitCost(src, dst *hjkl.Tile) int {
+	return 1
+}
+
+// AStar finds a shortest path between two Tile. The path excludes the start
+// but includes the goal. The start and goal are always considered passable,
+// so a path can be found to an occupied Tile. If there is no path, or if the
+// start is the goal, AStar returns nil.
+func AStar(start, goal *hjkl.Tile, opts ...Option) []*hjkl.Tile {
+	config := newConfig(opts)
+
+	prev := map[*hjkl.Tile]*hjkl.Tile{start: nil}
+	dist := map[*hjkl.Tile]int{start: 0}
+	frontier := &queue{{start, distance(start, goal)}}
+
+	for frontier.Len() > 0 {
+		curr := heap.Pop(frontier).(item)
+		if curr.tile == goal {
+			break
+		}
+		if curr.priority-distance(curr.tile, goal) > dist[curr.tile] {
+			continue // Stale entry which has since been improved.
+		}
+
+		for _, delta := range hjkl.CompassDirs {
+			next, ok := curr.tile.Neighbor(delta)
+			if !ok || (next != goal && !config.Pass(next)) {
+				continue
+			}
+			cost := dist[curr.tile] + config.Cost(curr.tile, next)
+			if old, seen := dist[next]; !seen || cost < old {
+				dist[next] = cost
+				prev[next] = curr.tile
+				heap.Push(frontier, item{next, cost + distance(next, goal)})
+			}
+		}
+	}
+
+	if _, found := prev[goal]; !found || goal == start {
+		return nil
+	}
+	var path []*hjkl.Tile
+	for t := goal; t != start; t = prev[t] {
+		path = append(path, t)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// DijkstraMap stores the cost of travelling from each Tile to the nearest of
+// a set of goal Tile. Tile which cannot reach any goal are absent.
+type DijkstraMap map[*hjkl.Tile]int
+
+// Dijkstra computes a DijkstraMap with the given goal Tile. The goals are
+// always included in the map, even if impassable.
+func Dijkstra(goals []*hjkl.Tile, opts ...Option) DijkstraMap {
+	seeds := make(map[*hjkl.Tile]int, len(goals))
+	for _, t := range goals {
+		seeds[t] = 0
+	}
+	return relax(seeds, newConfig(opts))
+}
+
+// Flee computes a DijkstraMap for fleeing from the goals of this one. Rolling
+// downhill on the resulting map leads away from the goals, but prefers routes
+// which escape rather than those which lead into dead ends. The coefficient
+// controls how aggressively to flee, with 1.2 being a typical value.
+func (m DijkstraMap) Flee(coef float64, opts ...Option) DijkstraMap {
+	seeds := make(map[*hjkl.Tile]int, len(m))
+	for t, v := range m {
+		seeds[t] = int(math.Round(-coef * float64(v)))
+	}
+	return relax(seeds, newConfig(opts))
+}
+
+// Downhill gets the direction from a Tile to the adjacent Tile with the lowest
+// value which is lower than the value of the Tile itself. The Tile need not
+// be in the map (e.g., it may be occupied by the Mob rolling downhill). If
+// there is no such adjacent Tile, Downhill returns false.
+func (m DijkstraMap) Downhill(t *hjkl.Tile) (hjkl.Vector, bool) {
+	best, found := math.MaxInt, false
+	if v, ok := m[t]; ok {
+		best = v
+	}
+
+	var dir hjkl.Vector
+	for _, delta := range hjkl.CompassDirs {
+		if adj, ok := t.Neighbor(delta); ok {
+			if v, ok := m[adj]; ok && v < best {
+				best, dir, found = v, delta, true
+			}
+		}
+	}
+	return dir, found
+}
+
+// relax runs Dijkstra's algorithm from seed Tile with initial values.
+func relax(seeds map[*hjkl.Tile]int, config *Config) DijkstraMap {
+	dist := make(DijkstraMap, len(seeds))
+	frontier := &queue{}
+	for t, v := range seeds {
+		dist[t] = v
+		heap.Push(frontier, item{t, v})
+	}
+
+	for frontier.Len() > 0 {
+		curr := heap.Pop(frontier).(item)
+		if curr.priority > dist[curr.tile] {
+			continue // Stale entry which has since been improved.
+		}
+
+		for _, delta := range hjkl.CompassDirs {
+			next, ok := curr.tile.Neighbor(delta)
+			if !ok || !config.Pass(next) {
+				continue
+			}
+			cost := curr.priority + config.Cost(curr.tile, next)
+			if old, seen := dist[next]; !seen || cost < old {
+				dist[next] = cost
+				heap.Push(frontier, item{next, cost})
+			}
+		}
+	}
+
+	return dist
+}
+
+// distance is the Chebyshev distance between the Offset of two Tile, which is
+// an admissible heuristic for eight-connected movement with unit cost.
+func distance(a, b *hjkl.Tile) int {
+	d := a.Offset.Sub(b.Offset)
+	return max(abs(d.X), abs(d.Y))
+}
+
+// abs returns the absolute value of an int.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// item is an entry in a queue.
+type item struct {
+	tile     *hjkl.Tile
+	priority int
+}
+
+// queue is a min-priority queue implementing heap.Interface.
+type queue []item
+
+func (q queue) Len() int           { return len(q) }
+func (q queue) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q queue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x any)        { *q = append(*q, x.(item)) }
+
+func (q *queue) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
@@ -0,0 +1,146 @@
+package path
+
+import (
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/gen"
+)
+
+// ValidPath returns true if each Tile in the path is adjacent to the last.
+func ValidPath(start *hjkl.Tile, path []*hjkl.Tile) bool {
+	prev := start
+	for _, t := range path {
+		adjacent := false
+		for _, adj := range prev.Neighbors() {
+			adjacent = adjacent || adj == t
+		}
+		if !adjacent {
+			return false
+		}
+		prev = t
+	}
+	return true
+}
+
+func TestAStar(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		".....",
+		"####.",
+		".....",
+		".####",
+		".....",
+	})
+	start, goal := level.At(hjkl.Vec(0, 0)), level.At(hjkl.Vec(4, 4))
+	path := AStar(start, goal)
+	if len(path) != 12 {
+		t.Errorf("AStar found path of length %d", len(path))
+	}
+	if !ValidPath(start, path) || path[len(path)-1] != goal {
+		t.Error("AStar found invalid path")
+	}
+}
+
+func TestAStar_OccupiedGoal(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		"...",
+	})
+	start, goal := level.At(hjkl.Vec(0, 0)), level.At(hjkl.Vec(2, 0))
+	hjkl.PlaceMob(hjkl.NewMob(hjkl.Ch('@')), start)
+	hjkl.PlaceMob(hjkl.NewMob(hjkl.Ch('D')), goal)
+	if path := AStar(start, goal); len(path) != 2 {
+		t.Error("AStar failed to path to occupied goal")
+	}
+}
+
+func TestAStar_NoPath(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		"..#..",
+		"..#..",
+	})
+	if path := AStar(level.At(hjkl.Vec(0, 0)), level.At(hjkl.Vec(4, 1))); path != nil {
+		t.Error("AStar found path through wall")
+	}
+	pass := WithPass(func(*hjkl.Tile) bool { return true })
+	if path := AStar(level.At(hjkl.Vec(0, 0)), level.At(hjkl.Vec(4, 1)), pass); len(path) != 4 {
+		t.Error("AStar ignored WithPass")
+	}
+}
+
+func TestAStar_Cost(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		".....",
+		".....",
+		".....",
+	})
+	// Make the straight path expensive, so the path should go around.
+	cost := WithCost(func(src, dst *hjkl.Tile) int {
+		if dst.Offset.Y == 1 && dst.Offset.X > 0 && dst.Offset.X < 4 {
+			return 10
+		}
+		return 1
+	})
+	start, goal := level.At(hjkl.Vec(0, 1)), level.At(hjkl.Vec(4, 1))
+	path := AStar(start, goal, cost)
+	if len(path) != 4 || !ValidPath(start, path) {
+		t.Fatal("AStar found invalid path")
+	}
+	for _, tile := range path[:3] {
+		if tile.Offset.Y == 1 {
+			t.Error("AStar ignored WithCost")
+		}
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		"....#",
+		".##.#",
+		"....#",
+	})
+	m := Dijkstra([]*hjkl.Tile{level.At(hjkl.Vec(0, 0)), level.At(hjkl.Vec(3, 2))})
+	cases := []struct {
+		Pos  hjkl.Vector
+		Want int
+	}{
+		{hjkl.Vec(0, 0), 0},
+		{hjkl.Vec(3, 2), 0},
+		{hjkl.Vec(1, 0), 1},
+		{hjkl.Vec(2, 0), 2},
+		{hjkl.Vec(3, 0), 2},
+		{hjkl.Vec(0, 2), 2},
+		{hjkl.Vec(1, 2), 2},
+	}
+	for _, c := range cases {
+		if got, ok := m[level.At(c.Pos)]; !ok || got != c.Want {
+			t.Errorf("Dijkstra value at %v was %d", c.Pos, got)
+		}
+	}
+	if _, ok := m[level.At(hjkl.Vec(4, 0))]; ok {
+		t.Error("Dijkstra included impassable Tile")
+	}
+}
+
+func TestDijkstraMap_Downhill(t *testing.T) {
+	level := gen.GenLevelMap([]string{
+		".......",
+	})
+	m := Dijkstra([]*hjkl.Tile{level.At(hjkl.Vec(0, 0))})
+
+	curr := level.At(hjkl.Vec(6, 0))
+	for steps := 0; curr != level.At(hjkl.Vec(0, 0)); steps++ {
+		dir, ok := m.Downhill(curr)
+		if !ok || dir != hjkl.Vec(-1, 0) || steps > 6 {
+			t.Fatal("Downhill failed to chase goal")
+		}
+		curr, _ = curr.Neighbor(dir)
+	}
+	if _, ok := m.Downhill(curr); ok {
+		t.Error("Downhill moved away from goal")
+	}
+
+	flee := m.Flee(1.2)
+	if dir, ok := flee.Downhill(level.At(hjkl.Vec(3, 0))); !ok || dir != hjkl.Vec(1, 0) {
+		t.Error("Flee failed to flee from goal")
+	}
+}
@@ -0,0 +1,110 @@
+package rand
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Dice describes a dice expression of the form NdS+B, such as 3d6+2.
+type Dice struct {
+	Count int
+	Sides int
+	Bonus int
+}
+
+// diceRE matches dice expressions with optional count and bonus.
+var diceRE = regexp.MustCompile(`^\s*(\d*)d(\d+)\s*(?:([+-])\s*(\d+))?\s*$|^\s*([+-]?\d+)\s*$`)
+
+// ParseDice parses a dice expression such as "3d6+2", "d20", "2d4-1" or "5".
+func ParseDice(s string) (Dice, error) {
+	m := diceRE.FindStringSubmatch(s)
+	if m == nil {
+		return Dice{}, fmt.Errorf("rand: invalid dice expression %q", s)
+	}
+
+	// A bare constant has no dice at all.
+	if m[5] != "" {
+		bonus, err := strconv.Atoi(m[5])
+		return Dice{Bonus: bonus}, err
+	}
+
+	d := Dice{Count: 1}
+	var err error
+	if m[1] != "" {
+		if d.Count, err = strconv.Atoi(m[1]); err != nil {
+			return Dice{}, err
+		}
+	}
+	if d.Sides, err = strconv.Atoi(m[2]); err != nil {
+		return Dice{}, err
+	}
+	if d.Sides == 0 {
+		return Dice{}, fmt.Errorf("rand: zero-sided dice in %q", s)
+	}
+	if m[4] != "" {
+		if d.Bonus, err = strconv.Atoi(m[4]); err != nil {
+			return Dice{}, err
+		}
+		if m[3] == "-" {
+			d.Bonus = -d.Bonus
+		}
+	}
+	return d, nil
+}
+
+// MustParseDice is like ParseDice but panics if the expression is invalid.
+func MustParseDice(s string) Dice {
+	d, err := ParseDice(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String converts Dice back into a dice expression.
+func (d Dice) String() string {
+	switch {
+	case d.Count == 0:
+		return strconv.Itoa(d.Bonus)
+	case d.Bonus > 0:
+		return fmt.Sprintf("%dd%d+%d", d.Count, d.Sides, d.Bonus)
+	case d.Bonus < 0:
+		return fmt.Sprintf("%dd%d-%d", d.Count, d.Sides, -d.Bonus)
+	default:
+		return fmt.Sprintf("%dd%d", d.Count, d.Sides)
+	}
+}
+
+// Min returns the lowest possible roll of the Dice.
+func (d Dice) Min() int {
+	return d.Count + d.Bonus
+}
+
+// Max returns the highest possible roll of the Dice.
+func (d Dice) Max() int {
+	return d.Count*d.Sides + d.Bonus
+}
+
+// RollWith rolls the Dice using the given Rand.
+func (d Dice) RollWith(r *Rand) int {
+	total := d.Bonus
+	for i := 0; i < d.Count; i++ {
+		total += r.Range(1, d.Sides)
+	}
+	return total
+}
+
+// Roll rolls the Dice using the Default generator.
+func (d Dice) Roll() int {
+	return d.RollWith(Default)
+}
+
+// Roll parses and rolls a dice expression using the Default generator.
+func Roll(s string) (int, error) {
+	d, err := ParseDice(s)
+	if err != nil {
+		return 0, err
+	}
+	return d.Roll(), nil
+}
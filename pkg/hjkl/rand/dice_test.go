@@ -0,0 +1,55 @@
+package rand
+
+import "testing"
+
+func TestParseDice(t *testing.T) {
+	cases := []struct {
+		Expr string
+		Want Dice
+		Str  string
+	}{
+		{"3d6+2", Dice{3, 6, 2}, "3d6+2"},
+		{"d20", Dice{1, 20, 0}, "1d20"},
+		{"2d4-1", Dice{2, 4, -1}, "2d4-1"},
+		{" 1d8 + 3 ", Dice{1, 8, 3}, "1d8+3"},
+		{"5", Dice{0, 0, 5}, "5"},
+		{"-2", Dice{0, 0, -2}, "-2"},
+	}
+	for _, c := range cases {
+		got, err := ParseDice(c.Expr)
+		if err != nil {
+			t.Errorf("ParseDice(%q) gave error %v", c.Expr, err)
+		}
+		if got != c.Want {
+			t.Errorf("ParseDice(%q) = %v", c.Expr, got)
+		}
+		if got.String() != c.Str {
+			t.Errorf("Dice.String() = %s", got.String())
+		}
+	}
+}
+
+func TestParseDice_Invalid(t *testing.T) {
+	cases := []string{"", "d", "3d", "3d0", "3x6", "3d6+", "3d6+2d4", "abc"}
+	for _, c := range cases {
+		if _, err := ParseDice(c); err == nil {
+			t.Errorf("ParseDice(%q) failed to give error", c)
+		}
+	}
+	if _, err := Roll("3d"); err == nil {
+		t.Error("Roll failed to give error")
+	}
+}
+
+func TestDice_Roll(t *testing.T) {
+	// The distribution of 2d3 is triangular over [2, 6].
+	d := MustParseDice("2d3-2")
+	exp := []int{1000, 2000, 3000, 2000, 1000}
+	RunX2TestCases("Dice(2d3-2).Roll()", t, exp, func() int {
+		x := d.Roll()
+		if x < d.Min() || x > d.Max() {
+			t.Fatal("Dice.Roll out of range")
+		}
+		return x
+	})
+}
@@ -0,0 +1,86 @@
+package rand
+
+import "math"
+
+// Normal returns a normally distributed float64 with the given mean and
+// standard deviation.
+func (r *Rand) Normal(mean, stddev float64) float64 {
+	// Box-Muller transform. Using 1-Float64 keeps the log argument in (0, 1].
+	u, v := 1-r.Float64(), r.Float64()
+	return mean + stddev*math.Sqrt(-2*math.Log(u))*math.Cos(2*math.Pi*v)
+}
+
+// Exponential returns an exponentially distributed float64 with the given
+// rate. It panics if rate <= 0.
+func (r *Rand) Exponential(rate float64) float64 {
+	if rate <= 0 {
+		panic("Invalid argument to Exponential")
+	}
+	return -math.Log(1-r.Float64()) / rate
+}
+
+// Geometric returns the number of failures before the first success in a
+// sequence of trials each succeeding with probability p. It panics if p <= 0
+// or p > 1.
+func (r *Rand) Geometric(p float64) int {
+	if p <= 0 || p > 1 {
+		panic("Invalid argument to Geometric")
+	}
+	if p == 1 {
+		return 0
+	}
+	return int(math.Floor(math.Log(1-r.Float64()) / math.Log(1-p)))
+}
+
+// ShuffleWith shuffles a slice in place using the given Rand.
+func ShuffleWith[T any](r *Rand, xs []T) {
+	for i := len(xs) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		xs[i], xs[j] = xs[j], xs[i]
+	}
+}
+
+// SampleWith returns k distinct elements of a slice in random order using the
+// given Rand. The slice itself is not modified. It panics if k < 0 or
+// k > len(xs).
+func SampleWith[T any](r *Rand, xs []T, k int) []T {
+	if k < 0 || k > len(xs) {
+		panic("Invalid argument to Sample")
+	}
+	// A partial Fisher-Yates shuffle on a copy, stopping after k elements.
+	pool := append([]T(nil), xs...)
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	return pool[:k:k]
+}
+
+// Normal returns a normally distributed float64 with the given mean and
+// standard deviation using the Default generator.
+func Normal(mean, stddev float64) float64 {
+	return Default.Normal(mean, stddev)
+}
+
+// Exponential returns an exponentially distributed float64 with the given
+// rate using the Default generator. It panics if rate <= 0.
+func Exponential(rate float64) float64 {
+	return Default.Exponential(rate)
+}
+
+// Geometric returns the number of failures before the first success using the
+// Default generator. It panics if p <= 0 or p > 1.
+func Geometric(p float64) int {
+	return Default.Geometric(p)
+}
+
+// Shuffle shuffles a slice in place using the Default generator.
+func Shuffle[T any](xs []T) {
+	ShuffleWith(Default, xs)
+}
+
+// Sample returns k distinct elements of a slice in random order using the
+// Default generator. It panics if k < 0 or k > len(xs).
+func Sample[T any](xs []T, k int) []T {
+	return SampleWith(Default, xs, k)
+}
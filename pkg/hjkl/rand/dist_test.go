@@ -0,0 +1,92 @@
+package rand
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestNormal(t *testing.T) {
+	// Bucket by standard deviation, using the 68-95-99.7 rule for expected.
+	exp := []int{228, 1359, 3413, 3413, 1359, 228}
+	RunX2TestCases("Normal(10, 2)", t, exp, func() int {
+		x := (Normal(10, 2) - 10) / 2
+		return min(max(int(math.Floor(x))+3, 0), 5)
+	})
+}
+
+func TestExponential(t *testing.T) {
+	// P(X < 1) = 1 - e^-2 for rate 2.
+	exp := []int{865, 135}
+	RunX2TestCases("Exponential(2)", t, exp, func() int {
+		if Exponential(2) < 1 {
+			return 0
+		}
+		return 1
+	})
+}
+
+func TestGeometric(t *testing.T) {
+	exp := []int{500, 250, 125, 125}
+	RunX2TestCases("Geometric(.5)", t, exp, func() int {
+		return min(Geometric(.5), 3)
+	})
+}
+
+func TestShuffle(t *testing.T) {
+	// Count the position where element 0 ends up.
+	exp := []int{200, 200, 200, 200, 200}
+	RunX2TestCases("Shuffle(xs)", t, exp, func() int {
+		xs := []int{0, 1, 2, 3, 4}
+		Shuffle(xs)
+		return slices.Index(xs, 0)
+	})
+}
+
+func TestSample(t *testing.T) {
+	xs := []int{0, 1, 2, 3, 4}
+	exp := []int{600, 600, 600, 600, 600}
+	var pending []int
+	RunX2TestCases("Sample(xs, 3)", t, exp, func() int {
+		if len(pending) == 0 {
+			pending = Sample(xs, 3)
+			seen := make(map[int]bool)
+			for _, x := range pending {
+				if seen[x] {
+					t.Fatal("Sample gave duplicate element")
+				}
+				seen[x] = true
+			}
+		}
+		x := pending[0]
+		pending = pending[1:]
+		return x
+	})
+	if !reflect.DeepEqual(xs, []int{0, 1, 2, 3, 4}) {
+		t.Error("Sample modified slice")
+	}
+}
+
+func TestDist_InvalidArgs(t *testing.T) {
+	cases := []struct {
+		name string
+		call func()
+	}{
+		{"Exponential(0)", func() { Exponential(0) }},
+		{"Geometric(0)", func() { Geometric(0) }},
+		{"Geometric(1.5)", func() { Geometric(1.5) }},
+		{"Sample(xs, -1)", func() { Sample([]int{1}, -1) }},
+		{"Sample(xs, 2)", func() { Sample([]int{1}, 2) }},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", c.name)
+				}
+			}()
+			c.call()
+		})
+	}
+}
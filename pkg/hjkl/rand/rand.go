@@ -3,8 +3,20 @@ package rand
 
 import "time"
 
-// The 64-bit state of the SplitMix64 generator used by Uint64.
-var state uint64
+// Rand is a SplitMix64 pseudo-random number generator. Each Rand is an
+// independent stream, so for example map generation and combat rolls can use
+// separate streams without one disturbing the determinism of the other.
+type Rand struct {
+	state uint64
+}
+
+// New creates a Rand seeded to a deterministic state.
+func New(seed uint64) *Rand {
+	return &Rand{state: seed}
+}
+
+// Default is the Rand used by the package-level functions.
+var Default = &Rand{}
 
 // init ensures that users don't have to manually seed the generator.
 func init() {
@@ -12,77 +24,97 @@ func init() {
 }
 
 // Seed seeds the random number generator to a deterministic state.
-func Seed(seed uint64) {
-	state = seed
+func (r *Rand) Seed(seed uint64) {
+	r.state = seed
 }
 
-// Seed seeds the random number generator using the current time.
-func SeedTime() {
-	Seed(uint64(time.Now().UnixNano()))
+// SeedTime seeds the random number generator using the current time.
+func (r *Rand) SeedTime() {
+	r.Seed(uint64(time.Now().UnixNano()))
+}
+
+// State gets the current state of the random number generator.
+func (r *Rand) State() uint64 {
+	return r.state
+}
+
+// SetState restores the random number generator to a state given by State.
+func (r *Rand) SetState(s uint64) {
+	r.state = s
+}
+
+// Split derives a new Rand whose stream is independent from this one. Split
+// advances this Rand, so splitting is itself deterministic.
+func (r *Rand) Split() *Rand {
+	return New(r.Uint64())
 }
 
 // Uint64 returns a uniform random uint64.
-func Uint64() uint64 {
+func (r *Rand) Uint64() uint64 {
 	// The SplitMix64 algorithm from Java 8's SplittableRandom class. This
 	// isn't a good generator per se, but it is good enough to pass BigCrush,
 	// and extremely fast with only 64 bits of state. We could of course reuse
 	// the generator from math/rand, but we provide a different API for hjkl so
 	// we might as well use a less clunky random source.
-	state += 0x9e3779b97f4a7c15
-	z := state
+	r.state += 0x9e3779b97f4a7c15
+	z := r.state
 	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
 	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
 	return z ^ (z >> 31)
 }
 
 // Float64 returns a uniform random float64 in [0, 1).
-func Float64() float64 {
+func (r *Rand) Float64() float64 {
 	// Floating point values are not uniformly distributed, so we can't just
 	// divide by 1<<64. Instead, we use just the 53 mantiass bits of float64.
-	return float64(Uint64()>>11) / float64(1<<53)
+	return float64(r.Uint64()>>11) / float64(1<<53)
 }
 
 // Intn returns a uniform random int in [0, n). It panics if n <= 0.
-func Intn(n int) int {
+func (r *Rand) Intn(n int) int {
 	if n <= 0 {
 		panic("Invalid argument to Intn")
 	}
-	return int(Uint64() % uint64(n))
+	return int(r.Uint64() % uint64(n))
 }
 
 // Range returns an int in [a, b]. It panics if b < a.
-func Range(a, b int) int {
+func (r *Rand) Range(a, b int) int {
 	if b < a {
 		panic("Invalid argument to Range")
 	}
-	return Intn(b-a+1) + a
+	return r.Intn(b-a+1) + a
 }
 
 // Chance returns true with probability p. It panics of p < 0 or p > 1.
-func Chance(p float64) bool {
+func (r *Rand) Chance(p float64) bool {
 	if p < 0 || p > 1 {
 		panic("Invalid argument to Chance")
 	}
-	return Float64() < p
+	return r.Float64() < p
 }
 
-// Choice returns a random element of a slice. It panics of len(xs) == 0.
-func Choice[T any](xs []T) T {
+// ChoiceWith returns a random element of a slice using the given Rand. It
+// panics of len(xs) == 0. Since methods cannot have type parameters, this is
+// the equivalent of Choice for a Rand.
+func ChoiceWith[T any](r *Rand, xs []T) T {
 	n := len(xs)
 	if n == 0 {
 		panic("Invalid argument to Choice")
 	}
-	return xs[Intn(n)]
+	return xs[r.Intn(n)]
 }
 
-// Choice returns a random element of a slice xs for which the filter function
-// f returns true. It panics if no such element exists in xs.
-func FilteredChoice[T any](xs []T, f func(T) bool) T {
+// FilteredChoiceWith returns a random element of a slice xs for which the
+// filter function f returns true using the given Rand. It panics if no such
+// element exists in xs. Since methods cannot have type parameters, this is the
+// equivalent of FilteredChoice for a Rand.
+func FilteredChoiceWith[T any](r *Rand, xs []T, f func(T) bool) T {
 	// Try rejection sampling first. Assuming that the probability of a random
 	// element passing the filter is reasonable (i.e., above 0.01), rejection
 	// sampling will likely run in constant time.
 	for i := 0; i < 100; i++ {
-		if x := xs[Intn(len(xs))]; f(x) {
+		if x := xs[r.Intn(len(xs))]; f(x) {
 			return x
 		}
 	}
@@ -98,5 +130,72 @@ func FilteredChoice[T any](xs []T, f func(T) bool) T {
 	if len(valid) == 0 {
 		panic("No valid choices to FilteredChoice")
 	}
-	return valid[Intn(len(valid))]
+	return valid[r.Intn(len(valid))]
+}
+
+// Seed seeds the Default generator to a deterministic state.
+func Seed(seed uint64) {
+	Default.Seed(seed)
+}
+
+// SeedTime seeds the Default generator using the current time.
+func SeedTime() {
+	Default.SeedTime()
+}
+
+// State gets the current state of the Default generator.
+func State() uint64 {
+	return Default.State()
+}
+
+// SetState restores the Default generator to a state given by State.
+func SetState(s uint64) {
+	Default.SetState(s)
+}
+
+// Split derives a new Rand whose stream is independent from Default.
+func Split() *Rand {
+	return Default.Split()
+}
+
+// Uint64 returns a uniform random uint64 using the Default generator.
+func Uint64() uint64 {
+	return Default.Uint64()
+}
+
+// Float64 returns a uniform random float64 in [0, 1) using the Default
+// generator.
+func Float64() float64 {
+	return Default.Float64()
+}
+
+// Intn returns a uniform random int in [0, n) using the Default generator. It
+// panics if n <= 0.
+func Intn(n int) int {
+	return Default.Intn(n)
+}
+
+// Range returns an int in [a, b] using the Default generator. It panics if
+// b < a.
+func Range(a, b int) int {
+	return Default.Range(a, b)
+}
+
+// Chance returns true with probability p using the Default generator. It
+// panics of p < 0 or p > 1.
+func Chance(p float64) bool {
+	return Default.Chance(p)
+}
+
+// Choice returns a random element of a slice using the Default generator. It
+// panics of len(xs) == 0.
+func Choice[T any](xs []T) T {
+	return ChoiceWith(Default, xs)
+}
+
+// FilteredChoice returns a random element of a slice xs for which the filter
+// function f returns true using the Default generator. It panics if no such
+// element exists in xs.
+func FilteredChoice[T any](xs []T, f func(T) bool) T {
+	return FilteredChoiceWith(Default, xs, f)
 }
@@ -244,3 +244,71 @@ func TestValidArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestState(t *testing.T) {
+	Seed(0xBAAAAAAD)
+	Uint64()
+	s := State()
+	want := []uint64{Uint64(), Uint64(), Uint64()}
+	SetState(s)
+	for _, w := range want {
+		if got := Uint64(); got != w {
+			t.Error("SetState failed to restore State")
+		}
+	}
+}
+
+func TestRand_Independent(t *testing.T) {
+	a, b := New(0x1337C0DE), New(0x1337C0DE)
+	Seed(0xBAAAAAAD)
+	for range 10 {
+		Uint64()
+		if a.Uint64() != b.Uint64() {
+			t.Fatal("Rand streams with same seed diverged")
+		}
+	}
+	if State() == a.State() {
+		t.Error("Rand shared state with Default")
+	}
+}
+
+func TestRand_State(t *testing.T) {
+	r := New(0xFEE15BAD)
+	r.Uint64()
+	s := r.State()
+	want := []uint64{r.Uint64(), r.Uint64(), r.Uint64()}
+	r.SetState(s)
+	for _, w := range want {
+		if got := r.Uint64(); got != w {
+			t.Error("Rand.SetState failed to restore State")
+		}
+	}
+}
+
+func TestRand_Split(t *testing.T) {
+	a, b := New(0xABCDEF01), New(0xABCDEF01)
+	sa, sb := a.Split(), b.Split()
+	for range 10 {
+		if sa.Uint64() != sb.Uint64() {
+			t.Fatal("Rand.Split was not deterministic")
+		}
+	}
+	if a.State() == sa.State() {
+		t.Error("Rand.Split shared state with parent")
+	}
+	if a.Uint64() != b.Uint64() {
+		t.Error("Rand.Split disturbed parent determinism")
+	}
+}
+
+func TestChoiceWith(t *testing.T) {
+	xs := []int{0, 1, 2, 3, 4}
+	exp := []int{200, 200, 200, 200, 200}
+	r := New(0x12345678)
+	RunX2TestCases("ChoiceWith(r, xs)", t, exp, func() int {
+		return ChoiceWith(r, xs)
+	})
+	RunX2TestCases("FilteredChoiceWith(r, xs)", t, []int{250, 0, 250, 250, 250}, func() int {
+		return FilteredChoiceWith(r, xs, func(x int) bool { return x != 1 })
+	})
+}
@@ -0,0 +1,124 @@
+package rand
+
+// WeightedChoiceWith returns a random element of a slice using the given
+// Rand, where each element is chosen with probability proportional to its
+// weight. It panics if the lengths of xs and weights differ, if any weight is
+// negative, or if the weights sum to zero.
+func WeightedChoiceWith[T any](r *Rand, xs []T, weights []float64) T {
+	total := sumWeights(xs, weights)
+	target := r.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return xs[i]
+		}
+		target -= w
+	}
+	// Floating point error can leave a sliver of target at the end, in which
+	// case the last element with positive weight is the correct choice.
+	for i := len(weights) - 1; ; i-- {
+		if weights[i] > 0 {
+			return xs[i]
+		}
+	}
+}
+
+// WeightedChoice returns a random element of a slice using the Default
+// generator, where each element is chosen with probability proportional to its
+// weight. It panics if the lengths of xs and weights differ, if any weight is
+// negative, or if the weights sum to zero.
+func WeightedChoice[T any](xs []T, weights []float64) T {
+	return WeightedChoiceWith(Default, xs, weights)
+}
+
+// WeightedTable supports repeated weighted choices from the same elements in
+// O(1) time per draw using Vose's alias method.
+type WeightedTable[T any] struct {
+	xs    []T
+	prob  []float64
+	alias []int
+}
+
+// NewWeightedTable creates a WeightedTable with the given elements and
+// weights. It panics if the lengths of xs and weights differ, if any weight is
+// negative, or if the weights sum to zero.
+func NewWeightedTable[T any](xs []T, weights []float64) *WeightedTable[T] {
+	n := len(xs)
+	total := sumWeights(xs, weights)
+
+	table := &WeightedTable[T]{
+		xs:    append([]T(nil), xs...),
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+
+	// Scale the weights so the average is 1, then partition them into those
+	// which are under and over the average.
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	// Pair each small column with a large one which tops it up to 1.
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		table.prob[s] = scaled[s]
+		table.alias[s] = l
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+
+	// Whatever remains is 1 up to floating point error.
+	for _, i := range large {
+		table.prob[i] = 1
+	}
+	for _, i := range small {
+		table.prob[i] = 1
+	}
+
+	return table
+}
+
+// DrawWith returns a random element of the WeightedTable using the given Rand.
+func (t *WeightedTable[T]) DrawWith(r *Rand) T {
+	i := r.Intn(len(t.xs))
+	if r.Float64() < t.prob[i] {
+		return t.xs[i]
+	}
+	return t.xs[t.alias[i]]
+}
+
+// Draw returns a random element of the WeightedTable using the Default
+// generator.
+func (t *WeightedTable[T]) Draw() T {
+	return t.DrawWith(Default)
+}
+
+// sumWeights validates the weights for xs and returns their total.
+func sumWeights[T any](xs []T, weights []float64) float64 {
+	if len(xs) != len(weights) {
+		panic("Mismatched weights to WeightedChoice")
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			panic("Negative weight to WeightedChoice")
+		}
+		total += w
+	}
+	if total <= 0 {
+		panic("No valid choices to WeightedChoice")
+	}
+	return total
+}
@@ -0,0 +1,55 @@
+package rand
+
+import "testing"
+
+func TestWeightedChoice(t *testing.T) {
+	xs := []int{0, 1, 2, 3}
+	weights := []float64{1, 0, 3, 6}
+	exp := []int{100, 0, 300, 600}
+	RunX2TestCases("WeightedChoice(xs, weights)", t, exp, func() int {
+		return WeightedChoice(xs, weights)
+	})
+}
+
+func TestWeightedTable(t *testing.T) {
+	xs := []int{0, 1, 2, 3, 4}
+	weights := []float64{5, 1, 0, 2.5, 1.5}
+	exp := []int{500, 100, 0, 250, 150}
+	table := NewWeightedTable(xs, weights)
+	RunX2TestCases("WeightedTable.Draw()", t, exp, func() int {
+		return table.Draw()
+	})
+}
+
+func TestWeightedTable_Deterministic(t *testing.T) {
+	table := NewWeightedTable([]rune("abc"), []float64{1, 2, 3})
+	a, b := New(0xBAAAAAAD), New(0xBAAAAAAD)
+	for range 100 {
+		if table.DrawWith(a) != table.DrawWith(b) {
+			t.Fatal("WeightedTable.DrawWith was not deterministic")
+		}
+	}
+}
+
+func TestWeightedChoice_InvalidArgs(t *testing.T) {
+	cases := []struct {
+		name string
+		call func()
+	}{
+		{"WeightedChoice(mismatched)", func() { WeightedChoice([]int{1, 2}, []float64{1}) }},
+		{"WeightedChoice(negative)", func() { WeightedChoice([]int{1, 2}, []float64{1, -1}) }},
+		{"WeightedChoice(zero)", func() { WeightedChoice([]int{1, 2}, []float64{0, 0}) }},
+		{"WeightedChoice(empty)", func() { WeightedChoice([]int{}, []float64{}) }},
+		{"NewWeightedTable(zero)", func() { NewWeightedTable([]int{1}, []float64{0}) }},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s did not panic", c.name)
+				}
+			}()
+			c.call()
+		})
+	}
+}
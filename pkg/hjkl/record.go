@@ -0,0 +1,169 @@
+package hjkl
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+)
+
+// TickInput is an optional interface for Terminal which supply the Input for
+// each tick directly instead of through the Input channel. When the Terminal
+// given to Run implements TickInput, Run calls NextTick each tick in place of
+// gathering Input from the channel, and terminates normally once NextTick
+// returns false.
+type TickInput interface {
+	NextTick() ([]Input, bool)
+}
+
+// recordHeader is the first entry in a recording. Size is the initial size of
+// the Terminal, which older recordings lack.
+type recordHeader struct {
+	Seed uint64
+	Size Vector
+}
+
+// recordTick is an entry in a recording. Ticks without any Input are
+// omitted, except for the final tick which marks the end of the recording.
+type recordTick struct {
+	Tick   int
+	Inputs []Input `json:",omitempty"`
+}
+
+// recorder writes a recording for WithRecord.
+type recorder struct {
+	enc     *json.Encoder
+	tick    int
+	written int
+}
+
+// newRecorder creates a recorder and writes the recording header.
+func newRecorder(w io.Writer, seed uint64, size Vector) (*recorder, error) {
+	r := &recorder{enc: json.NewEncoder(w)}
+	return r, r.enc.Encode(recordHeader{seed, size})
+}
+
+// record records the Input for a single tick.
+func (r *recorder) record(inputs []Input) error {
+	r.tick++
+	if len(inputs) == 0 {
+		return nil
+	}
+	r.written = r.tick
+	return r.enc.Encode(recordTick{r.tick, inputs})
+}
+
+// done records the final tick, unless it was already recorded.
+func (r *recorder) done() error {
+	if r.written == r.tick {
+		return nil
+	}
+	return r.enc.Encode(recordTick{Tick: r.tick})
+}
+
+// WithRecord gets a RunOption which records every tick of Input to a Writer.
+// Since a game is fully determined by its random seed and the Input given to
+// each Update, the seed used to create the Game should also be given so that
+// the recording can be replayed exactly with Replay.
+func WithRecord(w io.Writer, seed uint64) RunOption {
+	return func(r *RunConfig) {
+		r.Record = w
+		r.Seed = seed
+	}
+}
+
+// Replay is a Terminal which plays back a recording made with WithRecord.
+// Output is sent to an underlying Terminal, whose Input is only watched for
+// one of the ReplayQuitKeys, which stops the Replay early. A Replay reports the
+// size the Terminal had when recorded, so the game lays out exactly as it did,
+// regardless of the size of the underlying Terminal.
+type Replay struct {
+	Terminal
+	Seed uint64
+
+	size    Vector
+	ticks   []recordTick
+	tick    int
+	display chan Input
+}
+
+// ReplayQuitKeys are the Key which stop a Replay when pressed on the display.
+var ReplayQuitKeys = []Key{KeyEsc, KeyCtrlC, 'q'}
+
+// LoadReplay reads a recording made with WithRecord. The returned Replay
+// displays output using the given Terminal. Before running the replay, the
+// game should be recreated after seeding the random number generator with
+// the Replay Seed.
+func LoadReplay(r io.Reader, display Terminal) (*Replay, error) {
+	dec := json.NewDecoder(r)
+
+	var header recordHeader
+	if err := dec.Decode(&header); err != nil {
+		return nil, err
+	}
+
+	var ticks []recordTick
+	for {
+		var t recordTick
+		if err := dec.Decode(&t); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		ticks = append(ticks, t)
+	}
+
+	return &Replay{Terminal: display, Seed: header.Seed, size: header.Size, ticks: ticks}, nil
+}
+
+// Input starts watching the Input of the underlying Terminal, but returns nil
+// since a Replay uses NextTick for input.
+func (r *Replay) Input() chan Input {
+	r.display = r.Terminal.Input()
+	return nil
+}
+
+// Size gets the recorded size, or the size of the underlying Terminal if the
+// recording has none.
+func (r *Replay) Size() Vector {
+	if r.size == (Vector{}) {
+		return r.Terminal.Size()
+	}
+	return r.size
+}
+
+// NextTick implements TickInput for Replay. The Replay ends once every tick is
+// played back, or as soon as one of the ReplayQuitKeys is pressed.
+func (r *Replay) NextTick() ([]Input, bool) {
+	if r.quit() {
+		return nil, false
+	}
+	if len(r.ticks) == 0 {
+		return nil, false
+	}
+	r.tick++
+	if next := r.ticks[0]; next.Tick == r.tick {
+		r.ticks = r.ticks[1:]
+		return next.Inputs, true
+	}
+	return nil, true
+}
+
+// quit drains any pending Input from the underlying Terminal, and determines
+// whether any of it was one of the ReplayQuitKeys.
+func (r *Replay) quit() bool {
+	for {
+		select {
+		case in, ok := <-r.display:
+			if !ok {
+				r.display = nil
+				return false
+			}
+			if in.Kind == InputKey && slices.Contains(ReplayQuitKeys, in.Key) {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package hjkl
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+// NullTerm creates a MockTerm which discards output and has no input.
+func NullTerm() *MockTerm {
+	return &MockTerm{
+		InitFn:  func() error { return nil },
+		InputFn: func() chan Input { return nil },
+		DoneFn:  func() {},
+		SizeFn:  func() Vector { return Vec(80, 24) },
+		ClearFn: func() {},
+		BlitFn:  func(Vector, Glyph) {},
+		FlushFn: func() error { return nil },
+	}
+}
+
+// ScriptTerm creates a Replay which gives one batch of Input per tick.
+func ScriptTerm(batches [][]Input) *Replay {
+	r := &Replay{Terminal: NullTerm()}
+	for i, inputs := range batches {
+		r.ticks = append(r.ticks, recordTick{i + 1, inputs})
+	}
+	return r
+}
+
+func TestRecordReplay(t *testing.T) {
+	batches := [][]Input{
+		{KeyPress('h')},
+		nil,
+		{KeyPress('j'), {Kind: InputKey, Key: KeyArrowUp, Mod: ModShift | ModCtrl}},
+		nil,
+		{{Kind: InputMouse, Mouse: MouseLeft, Pos: Vec(3, 4)}},
+		{KeyPress('l'), {Kind: InputResize, Pos: Vec(100, 30)}},
+		{KeyPress(KeyEsc)},
+	}
+
+	var recorded [][]Input
+	game := &MockGame{
+		UpdateFn: func(ins []Input) error {
+			recorded = append(recorded, append([]Input(nil), ins...))
+			for _, in := range ins {
+				if in.Key == KeyEsc {
+					return Termination
+				}
+			}
+			return nil
+		},
+		DrawFn: func(Canvas) {},
+	}
+
+	var buf bytes.Buffer
+	err := Run(game, WithTerm(ScriptTerm(batches)), WithTPS(0), WithRecord(&buf, 0xBAAAAAAD))
+	if err != nil {
+		t.Fatal("Run with WithRecord gave error", err)
+	}
+	want := recorded
+
+	replay, err := LoadReplay(&buf, NullTerm())
+	if err != nil {
+		t.Fatal("LoadReplay gave error", err)
+	}
+	if replay.Seed != 0xBAAAAAAD {
+		t.Error("LoadReplay gave incorrect Seed")
+	}
+
+	recorded = nil
+	if err := Run(game, WithTerm(replay), WithTPS(0)); err != nil {
+		t.Fatal("Run with Replay gave error", err)
+	}
+	if !reflect.DeepEqual(recorded, want) {
+		t.Errorf("Replay gave %v instead of %v", recorded, want)
+	}
+}
+
+func TestReplay_End(t *testing.T) {
+	ticks := 0
+	game := &MockGame{
+		UpdateFn: func([]Input) error {
+			ticks++
+			return nil
+		},
+		DrawFn: func(Canvas) {},
+	}
+
+	var buf bytes.Buffer
+	script := ScriptTerm([][]Input{{KeyPress('a')}, nil, nil, nil})
+	if err := Run(game, WithTerm(script), WithTPS(0), WithRecord(&buf, 0)); err != nil {
+		t.Fatal("Run with WithRecord gave error", err)
+	}
+
+	replay, err := LoadReplay(&buf, NullTerm())
+	if err != nil {
+		t.Fatal("LoadReplay gave error", err)
+	}
+	ticks = 0
+	if err := Run(game, WithTerm(replay), WithTPS(0)); err != nil {
+		t.Fatal("Run with Replay gave error", err)
+	}
+	if ticks != 4 {
+		t.Errorf("Replay ran %d ticks instead of 4", ticks)
+	}
+}
+
+// MockResizer is a MockGame which is also a Resizer.
+type MockResizer struct {
+	MockGame
+	ResizeFn func(Vector)
+}
+
+func (m *MockResizer) Resize(v Vector) { m.ResizeFn(v) }
+
+func TestReplay_Size(t *testing.T) {
+	var size Vector
+	game := &MockResizer{
+		MockGame: MockGame{
+			UpdateFn: func([]Input) error { return nil },
+			DrawFn:   func(Canvas) {},
+		},
+		ResizeFn: func(v Vector) { size = v },
+	}
+
+	var buf bytes.Buffer
+	script := ScriptTerm([][]Input{{KeyPress('a')}})
+	script.Terminal.(*MockTerm).SizeFn = func() Vector { return Vec(100, 40) }
+	if err := Run(game, WithTerm(script), WithTPS(0), WithRecord(&buf, 0)); err != nil {
+		t.Fatal("Run with WithRecord gave error", err)
+	}
+
+	replay, err := LoadReplay(&buf, NullTerm())
+	if err != nil {
+		t.Fatal("LoadReplay gave error", err)
+	}
+	size = Vector{}
+	if err := Run(game, WithTerm(replay), WithTPS(0)); err != nil {
+		t.Fatal("Run with Replay gave error", err)
+	}
+	if size != Vec(100, 40) {
+		t.Errorf("Replay gave size %v instead of the recorded size", size)
+	}
+}
+
+func TestReplay_Quit(t *testing.T) {
+	ticks := 0
+	game := &MockGame{
+		UpdateFn: func([]Input) error {
+			ticks++
+			return nil
+		},
+		DrawFn: func(Canvas) {},
+	}
+
+	display := NullTerm()
+	keys := make(chan Input, 1)
+	display.InputFn = func() chan Input { return keys }
+	replay := ScriptTerm([][]Input{nil, nil, nil, {KeyPress('a')}, nil})
+	replay.Terminal = display
+	display.FlushFn = func() error {
+		if ticks == 2 {
+			keys <- KeyPress(KeyCtrlC)
+		}
+		return nil
+	}
+
+	if err := Run(game, WithTerm(replay), WithTPS(0)); err != nil {
+		t.Fatal("Run with Replay gave error", err)
+	}
+	if ticks != 2 {
+		t.Errorf("Replay ran %d ticks instead of stopping after 2", ticks)
+	}
+}
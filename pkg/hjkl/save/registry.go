@@ -0,0 +1,116 @@
+package save
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Marshaler is implemented by Component which need to serialize references
+// to Tile or Mob. Components which do not implement Marshaler are encoded
+// using encoding/json.
+type Marshaler interface {
+	MarshalState(*Refs) ([]byte, error)
+}
+
+// Unmarshaler is implemented by Component which need to deserialize
+// references to Tile or Mob. Components which do not implement Unmarshaler
+// are decoded using encoding/json.
+type Unmarshaler interface {
+	UnmarshalState(*Refs, []byte) error
+}
+
+// Data needed to encode and decode registered Component.
+var (
+	names = make(map[reflect.Type]string)
+	types = make(map[string]reflect.Type)
+)
+
+// Register registers a Component type so that it can be saved and loaded. The
+// Component must be a pointer to T, and the name must be unique and should
+// never change once saves exist which use it. Register panics if either the
+// type or the name has already been registered.
+func Register[T any](name string) {
+	typ := reflect.TypeFor[*T]()
+	if _, ok := names[typ]; ok {
+		panic(fmt.Sprintf("save: type %v registered twice", typ))
+	}
+	if _, ok := types[name]; ok {
+		panic(fmt.Sprintf("save: name %s registered twice", name))
+	}
+	names[typ] = name
+	types[name] = typ
+}
+
+// encodeComponent serializes a registered Component.
+func encodeComponent(refs *Refs, c any) (componentData, error) {
+	name, ok := names[reflect.TypeOf(c)]
+	if !ok {
+		return componentData{}, fmt.Errorf("save: unregistered Component type %T", c)
+	}
+
+	var data []byte
+	var err error
+	if m, ok := c.(Marshaler); ok {
+		data, err = m.MarshalState(refs)
+	} else {
+		data, err = json.Marshal(c)
+	}
+	if err != nil {
+		return componentData{}, err
+	}
+	return componentData{name, data}, nil
+}
+
+// decodeComponent deserializes a registered Component.
+func decodeComponent(refs *Refs, cd componentData) (any, error) {
+	typ, ok := types[cd.Name]
+	if !ok {
+		return nil, fmt.Errorf("save: unregistered Component name %s", cd.Name)
+	}
+
+	c := reflect.New(typ.Elem()).Interface()
+	var err error
+	if u, ok := c.(Unmarshaler); ok {
+		err = u.UnmarshalState(refs, cd.Data)
+	} else {
+		err = json.Unmarshal(cd.Data, c)
+	}
+	return c, err
+}
+
+// Migration upgrades the top-level fields of a save by a single version.
+type Migration func(map[string]json.RawMessage) error
+
+// migrations maps each version to the Migration to the next version.
+var migrations = make(map[int]Migration)
+
+// RegisterMigration registers a Migration from the given version to the next.
+func RegisterMigration(from int, m Migration) {
+	migrations[from] = m
+}
+
+// migrate upgrades a save to the current Version.
+func migrate(raw map[string]json.RawMessage) error {
+	var version int
+	if err := json.Unmarshal(raw["Version"], &version); err != nil {
+		return fmt.Errorf("save: missing version: %w", err)
+	}
+	if version > Version {
+		return fmt.Errorf("save: version %d is newer than %d", version, Version)
+	}
+
+	for ; version < Version; version++ {
+		m, ok := migrations[version]
+		if !ok {
+			return fmt.Errorf("save: no migration from version %d", version)
+		}
+		if err := m(raw); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(version)
+	raw["Version"] = data
+	return err
+}
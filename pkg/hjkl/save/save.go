@@ -0,0 +1,364 @@
+// Package save provides serialization of game state.
+package save
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/clock"
+)
+
+// Version is the current version of the save format.
+const Version = 2
+
+func init() {
+	RegisterMigration(1, migrateLevel)
+}
+
+// migrateLevel upgrades a version 1 save, which predates Level, by giving it
+// the Size of a Level whenever its Tile exactly fill one.
+func migrateLevel(raw map[string]json.RawMessage) error {
+	var tiles []struct{ Offset hjkl.Vector }
+	if data, ok := raw["Tiles"]; ok {
+		if err := json.Unmarshal(data, &tiles); err != nil {
+			return err
+		}
+	}
+	if len(tiles) == 0 {
+		return nil
+	}
+
+	var size hjkl.Vector
+	seen := make(map[hjkl.Vector]bool, len(tiles))
+	for _, t := range tiles {
+		if t.Offset.X < 0 || t.Offset.Y < 0 || seen[t.Offset] {
+			return nil
+		}
+		seen[t.Offset] = true
+		size = hjkl.Vec(max(size.X, t.Offset.X+1), max(size.Y, t.Offset.Y+1))
+	}
+	if size.X*size.Y != len(tiles) {
+		return nil
+	}
+
+	data, err := json.Marshal(size)
+	raw["Size"] = data
+	return err
+}
+
+// State is the game state which can be saved and loaded. If Level is set, its
+// Tile are saved in place of Tiles, and Load restores both the Level and its
+// Tiles.
+type State struct {
+	Tiles  []*hjkl.Tile
+	Level  *hjkl.Level
+	Player *hjkl.Mob
+	Clock  *clock.Clock[*hjkl.Mob]
+	Rand   uint64
+}
+
+// snapshot is the serialized form of a State.
+type snapshot struct {
+	Version int
+	Size    hjkl.Vector
+	Tiles   []tileData
+	Mobs    []mobData
+	Player  int
+	Clock   []clockData
+	Rand    uint64
+}
+
+// tileData is the serialized form of a Tile.
+type tileData struct {
+	Offset     hjkl.Vector
+	Face       hjkl.Glyph
+	Pass       bool
+	Occupant   int
+	Adjacent   []adjacentData
+	Components []componentData
+}
+
+// adjacentData is the serialized form of a single Tile Adjacent link.
+type adjacentData struct {
+	Delta hjkl.Vector
+	Tile  int
+}
+
+// mobData is the serialized form of a Mob.
+type mobData struct {
+	Face       hjkl.Glyph
+	Pos        int
+	Components []componentData
+}
+
+// clockData is the serialized form of a scheduled Mob.
+type clockData struct {
+	Mob   int
+	Delta int
+}
+
+// componentData is the serialized form of a registered Component.
+type componentData struct {
+	Name string
+	Data json.RawMessage
+}
+
+// Refs maps Tile and Mob to and from the integer ids used in a save. Refs is
+// used by Marshaler and Unmarshaler to serialize references to game objects.
+// The id -1 is always used for nil.
+type Refs struct {
+	tiles   []*hjkl.Tile
+	mobs    []*hjkl.Mob
+	tileIDs map[*hjkl.Tile]int
+	mobIDs  map[*hjkl.Mob]int
+}
+
+// TileID gets the id of a Tile.
+func (r *Refs) TileID(t *hjkl.Tile) int {
+	if id, ok := r.tileIDs[t]; ok {
+		return id
+	}
+	return -1
+}
+
+// Tile gets the Tile with the given id.
+func (r *Refs) Tile(id int) *hjkl.Tile {
+	if 0 <= id && id < len(r.tiles) {
+		return r.tiles[id]
+	}
+	return nil
+}
+
+// MobID gets the id of a Mob.
+func (r *Refs) MobID(m *hjkl.Mob) int {
+	if id, ok := r.mobIDs[m]; ok {
+		return id
+	}
+	return -1
+}
+
+// Mob gets the Mob with the given id.
+func (r *Refs) Mob(id int) *hjkl.Mob {
+	if 0 <= id && id < len(r.mobs) {
+		return r.mobs[id]
+	}
+	return nil
+}
+
+// newRefs creates Refs for the given Tile and Mob.
+func newRefs(tiles []*hjkl.Tile, mobs []*hjkl.Mob) *Refs {
+	r := &Refs{
+		tiles:   tiles,
+		mobs:    mobs,
+		tileIDs: make(map[*hjkl.Tile]int, len(tiles)),
+		mobIDs:  make(map[*hjkl.Mob]int, len(mobs)),
+	}
+	for i, t := range tiles {
+		r.tileIDs[t] = i
+	}
+	for i, m := range mobs {
+		r.mobIDs[m] = i
+	}
+	return r
+}
+
+// Save writes the State to a Writer. Every Mob is saved so long as it is
+// either the Player or occupies one of the Tile. Every Component must be
+// registered with Register, and every Tile linked by Adjacent must be
+// included in the State Tiles. The neighbors given by a Level are implied by
+// its Size, so only explicit Adjacent links are saved.
+func Save(w io.Writer, s *State) error {
+	tiles := s.Tiles
+	var size hjkl.Vector
+	if s.Level != nil {
+		tiles, size = s.Level.Tiles, s.Level.Size
+	}
+
+	// Collect the Mob to save, in a deterministic order.
+	var mobs []*hjkl.Mob
+	if s.Player != nil {
+		mobs = append(mobs, s.Player)
+	}
+	for _, t := range tiles {
+		if t.Occupant != nil && t.Occupant != s.Player {
+			mobs = append(mobs, t.Occupant)
+		}
+	}
+	refs := newRefs(tiles, mobs)
+
+	snap := snapshot{
+		Version: Version,
+		Size:    size,
+		Tiles:   make([]tileData, len(tiles)),
+		Mobs:    make([]mobData, len(mobs)),
+		Player:  refs.MobID(s.Player),
+		Rand:    s.Rand,
+	}
+
+	for i, t := range tiles {
+		data := tileData{
+			Offset:   t.Offset,
+			Face:     t.Face,
+			Pass:     t.Pass,
+			Occupant: refs.MobID(t.Occupant),
+		}
+		for _, delta := range hjkl.CompassDirs {
+			if adj, ok := t.Adjacent[delta]; ok {
+				id := refs.TileID(adj)
+				if id == -1 {
+					return fmt.Errorf("save: Tile at %v links to unsaved Tile", t.Offset)
+				}
+				data.Adjacent = append(data.Adjacent, adjacentData{delta, id})
+			}
+		}
+		for _, c := range t.Components {
+			cd, err := encodeComponent(refs, c)
+			if err != nil {
+				return err
+			}
+			data.Components = append(data.Components, cd)
+		}
+		snap.Tiles[i] = data
+	}
+
+	for i, m := range mobs {
+		data := mobData{
+			Face: m.Face,
+			Pos:  refs.TileID(m.Pos),
+		}
+		for _, c := range m.Components {
+			cd, err := encodeComponent(refs, c)
+			if err != nil {
+				return err
+			}
+			data.Components = append(data.Components, cd)
+		}
+		snap.Mobs[i] = data
+	}
+
+	// Save the Clock in order, so that Mob due at the same time still act in
+	// the same order once loaded.
+	if s.Clock != nil {
+		for m, delta := range s.Clock.All() {
+			if id := refs.MobID(m); id != -1 {
+				snap.Clock = append(snap.Clock, clockData{id, delta})
+			}
+		}
+	}
+
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// Load reads a State from a Reader. Saves from older versions are upgraded
+// using the migrations given to RegisterMigration.
+func Load(r io.Reader) (*State, error) {
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	if err := migrate(raw); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, err
+	}
+
+	// Allocate every Tile and Mob first so that references can be resolved.
+	tiles := make([]*hjkl.Tile, len(snap.Tiles))
+	for i := range tiles {
+		tiles[i] = &hjkl.Tile{}
+	}
+	mobs := make([]*hjkl.Mob, len(snap.Mobs))
+	for i := range mobs {
+		mobs[i] = &hjkl.Mob{}
+	}
+	refs := newRefs(tiles, mobs)
+
+	for i, data := range snap.Tiles {
+		t := tiles[i]
+		t.Offset = data.Offset
+		t.Face = data.Face
+		t.Pass = data.Pass
+		t.Occupant = refs.Mob(data.Occupant)
+		for _, adj := range data.Adjacent {
+			dst := refs.Tile(adj.Tile)
+			if dst == nil {
+				return nil, fmt.Errorf("save: invalid Tile id %d", adj.Tile)
+			}
+			t.Link(adj.Delta, dst)
+		}
+		for _, cd := range data.Components {
+			c, err := decodeComponent(refs, cd)
+			if err != nil {
+				return nil, err
+			}
+			tc, ok := c.(hjkl.Component[*hjkl.Tile])
+			if !ok {
+				return nil, fmt.Errorf("save: %s is not a Tile Component", cd.Name)
+			}
+			t.Components.Add(tc)
+		}
+	}
+
+	for i, data := range snap.Mobs {
+		m := mobs[i]
+		m.Face = data.Face
+		m.Pos = refs.Tile(data.Pos)
+		for _, cd := range data.Components {
+			c, err := decodeComponent(refs, cd)
+			if err != nil {
+				return nil, err
+			}
+			mc, ok := c.(hjkl.Component[*hjkl.Mob])
+			if !ok {
+				return nil, fmt.Errorf("save: %s is not a Mob Component", cd.Name)
+			}
+			m.Components.Add(mc)
+		}
+	}
+
+	c := clock.New[*hjkl.Mob]()
+	for _, data := range snap.Clock {
+		m := refs.Mob(data.Mob)
+		if m == nil {
+			return nil, fmt.Errorf("save: invalid Mob id %d", data.Mob)
+		}
+		c.Schedule(m, data.Delta)
+	}
+
+	var level *hjkl.Level
+	if snap.Size != (hjkl.Vector{}) {
+		grid := make(map[hjkl.Vector]*hjkl.Tile, len(tiles))
+		for _, t := range tiles {
+			grid[t.Offset] = t
+		}
+		if len(grid) != len(tiles) || snap.Size.X*snap.Size.Y != len(tiles) {
+			return nil, fmt.Errorf("save: Level size %v does not match Tiles", snap.Size)
+		}
+		for y := 0; y < snap.Size.Y; y++ {
+			for x := 0; x < snap.Size.X; x++ {
+				if grid[hjkl.Vec(x, y)] == nil {
+					return nil, fmt.Errorf("save: Level is missing Tile at %v", hjkl.Vec(x, y))
+				}
+			}
+		}
+		level = hjkl.NewLevel(snap.Size.X, snap.Size.Y, func(v hjkl.Vector) *hjkl.Tile {
+			return grid[v]
+		})
+	}
+
+	return &State{
+		Tiles:  tiles,
+		Level:  level,
+		Player: refs.Mob(snap.Player),
+		Clock:  c,
+		Rand:   snap.Rand,
+	}, nil
+}
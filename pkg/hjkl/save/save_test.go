@@ -0,0 +1,235 @@
+package save
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/clock"
+	"github.com/jefflund/stones/pkg/hjkl/gen"
+)
+
+type TestStats struct {
+	Health int
+}
+
+func (*TestStats) Handle(*hjkl.Mob, hjkl.Event) {}
+
+type TestHome struct {
+	Home *hjkl.Tile
+}
+
+func (*TestHome) Handle(*hjkl.Mob, hjkl.Event) {}
+
+func (c *TestHome) MarshalState(r *Refs) ([]byte, error) {
+	return json.Marshal(r.TileID(c.Home))
+}
+
+func (c *TestHome) UnmarshalState(r *Refs, data []byte) error {
+	var id int
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	c.Home = r.Tile(id)
+	return nil
+}
+
+func init() {
+	Register[TestStats]("save.TestStats")
+	Register[TestHome]("save.TestHome")
+}
+
+func TestSaveLoad(t *testing.T) {
+	tiles := gen.GenTileGrid(4, 3, hjkl.NewTile)
+	tiles[5].Pass = false
+	tiles[5].Face = hjkl.Ch('#')
+
+	hero := hjkl.NewMob(hjkl.Ch('@'))
+	hero.Components.Add(&TestStats{10})
+	hero.Components.Add(&TestHome{tiles[1]})
+	hjkl.PlaceMob(hero, tiles[0])
+	mob := hjkl.NewMob(hjkl.ChFg('D', hjkl.ColorRed))
+	mob.Components.Add(&TestStats{3})
+	hjkl.PlaceMob(mob, tiles[7])
+
+	c := clock.New[*hjkl.Mob]()
+	c.Schedule(mob, 4)
+	c.Schedule(hero, 2)
+
+	var buf bytes.Buffer
+	state := &State{Tiles: tiles, Player: hero, Clock: c, Rand: 0xBAAAAAAD}
+	if err := Save(&buf, state); err != nil {
+		t.Fatal("Save gave error", err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal("Load gave error", err)
+	}
+
+	if len(loaded.Tiles) != len(tiles) {
+		t.Fatal("Load gave incorrect number of Tile")
+	}
+	for i, got := range loaded.Tiles {
+		want := tiles[i]
+		if got.Offset != want.Offset || got.Face != want.Face || got.Pass != want.Pass {
+			t.Errorf("Load gave incorrect Tile %d", i)
+		}
+		if len(got.Adjacent) != len(want.Adjacent) {
+			t.Errorf("Load gave incorrect Adjacent for Tile %d", i)
+		}
+		for delta, adj := range got.Adjacent {
+			if adj.Offset != want.Adjacent[delta].Offset {
+				t.Errorf("Load gave incorrect Adjacent for Tile %d", i)
+			}
+		}
+	}
+
+	p := loaded.Player
+	if p == nil || p.Face != hero.Face || p.Pos != loaded.Tiles[0] || loaded.Tiles[0].Occupant != p {
+		t.Fatal("Load gave incorrect Player")
+	}
+	if len(p.Components) != 2 {
+		t.Fatal("Load gave incorrect Player Components")
+	}
+	if s, ok := p.Components[0].(*TestStats); !ok || s.Health != 10 {
+		t.Error("Load gave incorrect json Component")
+	}
+	if h, ok := p.Components[1].(*TestHome); !ok || h.Home != loaded.Tiles[1] {
+		t.Error("Load gave incorrect Marshaler Component")
+	}
+
+	m := loaded.Tiles[7].Occupant
+	if m == nil || m.Face != mob.Face || m.Pos != loaded.Tiles[7] {
+		t.Fatal("Load gave incorrect Mob")
+	}
+	if delta, ok := loaded.Clock.Delta(m); !ok || delta != 4 {
+		t.Error("Load gave incorrect Clock for Mob")
+	}
+	if delta, ok := loaded.Clock.Delta(p); !ok || delta != 2 {
+		t.Error("Load gave incorrect Clock for Player")
+	}
+	if loaded.Rand != 0xBAAAAAAD {
+		t.Error("Load gave incorrect Rand")
+	}
+}
+
+func TestSave_Unregistered(t *testing.T) {
+	tiles := gen.GenTileGrid(1, 1, hjkl.NewTile)
+	hero := hjkl.NewMob(hjkl.Ch('@'))
+	hero.Components.Add(hjkl.ComponentFunc[*hjkl.Mob](func(*hjkl.Mob, hjkl.Event) {}))
+	hjkl.PlaceMob(hero, tiles[0])
+	if err := Save(&bytes.Buffer{}, &State{Tiles: tiles, Player: hero}); err == nil {
+		t.Error("Save failed to reject unregistered Component")
+	}
+}
+
+func TestLoad_Migration(t *testing.T) {
+	RegisterMigration(0, func(raw map[string]json.RawMessage) error {
+		raw["Rand"] = raw["Seed"]
+		delete(raw, "Seed")
+		return nil
+	})
+	defer delete(migrations, 0)
+
+	r := strings.NewReader(`{"Version":0,"Seed":42,"Player":-1}`)
+	loaded, err := Load(r)
+	if err != nil {
+		t.Fatal("Load gave error", err)
+	}
+	if loaded.Rand != 42 {
+		t.Error("Load failed to apply migration")
+	}
+}
+
+func TestLoad_NewerVersion(t *testing.T) {
+	r := strings.NewReader(`{"Version":1000}`)
+	if _, err := Load(r); err == nil {
+		t.Error("Load accepted save from future version")
+	}
+}
+
+func TestSaveLoad_Level(t *testing.T) {
+	level := gen.GenLevel(4, 3, hjkl.NewTile)
+	hero := hjkl.NewMob(hjkl.Ch('@'))
+	level.Mobs.Spawn(hero, level.At(hjkl.Vec(2, 1)))
+
+	var buf bytes.Buffer
+	if err := Save(&buf, &State{Level: level, Player: hero}); err != nil {
+		t.Fatal("Save gave error", err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal("Load gave error", err)
+	}
+
+	got := loaded.Level
+	if got == nil || got.Size != level.Size {
+		t.Fatal("Load gave incorrect Level")
+	}
+	for _, tile := range got.Tiles {
+		if tile.Adjacent != nil {
+			t.Error("Load added links implied by the Level")
+		}
+	}
+	p := loaded.Player
+	if p.Pos != got.At(hjkl.Vec(2, 1)) || !got.Mobs.Contains(p) {
+		t.Error("Load gave incorrect Player in Level")
+	}
+	if east, ok := p.Pos.Neighbor(hjkl.Vec(1, 0)); !ok || east != got.At(hjkl.Vec(3, 1)) {
+		t.Error("Load gave Level without neighbors")
+	}
+}
+
+func TestLoad_LevelMigration(t *testing.T) {
+	r := strings.NewReader(`{"Version":1,"Player":-1,"Tiles":[
+		{"Offset":{"X":1,"Y":0},"Occupant":-1},
+		{"Offset":{"X":0,"Y":0},"Occupant":-1}
+	]}`)
+	loaded, err := Load(r)
+	if err != nil {
+		t.Fatal("Load gave error", err)
+	}
+	if loaded.Level == nil || loaded.Level.Size != hjkl.Vec(2, 1) {
+		t.Fatal("Load failed to migrate Tile into a Level")
+	}
+	if loaded.Level.At(hjkl.Vec(1, 0)) != loaded.Tiles[0] {
+		t.Error("Load placed migrated Tile by index instead of Offset")
+	}
+}
+
+func TestSaveLoad_ClockOrder(t *testing.T) {
+	tiles := gen.GenTileGrid(3, 1, hjkl.NewTile)
+	var mobs []*hjkl.Mob
+	c := clock.New[*hjkl.Mob]()
+	for i, tile := range tiles {
+		m := hjkl.NewMob(hjkl.Ch(rune('a' + i)))
+		hjkl.PlaceMob(m, tile)
+		mobs = append(mobs, m)
+	}
+	// Schedule in reverse, so the Clock order differs from the save order.
+	for i := len(mobs) - 1; i >= 0; i-- {
+		c.Schedule(mobs[i], 5)
+	}
+
+	var buf bytes.Buffer
+	if err := Save(&buf, &State{Tiles: tiles, Clock: c}); err != nil {
+		t.Fatal("Save gave error", err)
+	}
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal("Load gave error", err)
+	}
+
+	var want, got []rune
+	for m := range c.All() {
+		want = append(want, m.Face.Ch)
+	}
+	for m := range loaded.Clock.All() {
+		got = append(got, m.Face.Ch)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Load gave Clock order %q instead of %q", string(got), string(want))
+	}
+}
@@ -1,5 +1,10 @@
 package hjkl
 
+import (
+	"iter"
+	"slices"
+)
+
 // Widget is an object which can draw itself on a Canvas.
 type Widget interface {
 	Draw(Canvas)
@@ -15,6 +20,15 @@ func (s Screen) Draw(c Canvas) {
 	}
 }
 
+// Resize resizes each constituent Widget which implements Resizer.
+func (s Screen) Resize(size Vector) {
+	for _, w := range s {
+		if r, ok := w.(Resizer); ok {
+			r.Resize(size)
+		}
+	}
+}
+
 // Window serves as a base to Widget which require relative drawing.
 type Window struct {
 	Pos  Vector
@@ -28,20 +42,68 @@ func (w *Window) RelBlit(c Canvas, v Vector, g Glyph) {
 	}
 }
 
+// Place moves and resizes the Window.
+func (w *Window) Place(pos, size Vector) {
+	w.Pos, w.Size = pos, size
+}
+
+// View describes which Tile an observer currently sees or remembers seeing.
+type View interface {
+	Visible(*Tile) bool
+	Remembered(*Tile) (Glyph, bool)
+}
+
 // TilesWidget is a Widget which draws a collection of Tile.
+//
+// If Level is set, the Tile are drawn from the Level instead, so only the Tile
+// within the Window are visited.
 type TilesWidget struct {
 	Window
 	Tiles []*Tile
+	Level *Level
+
+	// View optionally restricts drawing to what an observer knows. If View is
+	// nil, every Tile is drawn using its live Face.
+	View View
 }
 
 // NewTilesWidget creates a TileWidget with the given collection of Tile.
 func NewTilesWidget(pos, size Vector, tiles []*Tile) *TilesWidget {
-	return &TilesWidget{Window{pos, size}, tiles}
+	return &TilesWidget{Window: Window{pos, size}, Tiles: tiles}
+}
+
+// NewLevelWidget creates a TilesWidget which draws the given Level.
+func NewLevelWidget(pos, size Vector, level *Level) *TilesWidget {
+	return &TilesWidget{Window: Window{pos, size}, Tiles: level.Tiles, Level: level}
 }
 
 // Draw draws the collection of Tile.
 func (w *TilesWidget) Draw(c Canvas) {
-	for _, t := range w.Tiles {
-		w.RelBlit(c, t.Offset, Get(t, &Face{}))
+	w.drawFrom(c, Vector{})
+}
+
+// window iterates over the Tile which might be drawn in the Window, given
+// the Tile offset drawn at the top-left corner of the Window.
+func (w *TilesWidget) window(origin Vector) iter.Seq[*Tile] {
+	if w.Level != nil {
+		return w.Level.Region(origin, origin.Add(w.Size))
 	}
+	return slices.Values(w.Tiles)
+}
+
+// drawFrom draws the collection of Tile, with the given Tile offset drawn at
+// the top-left corner of the Window.
+func (w *TilesWidget) drawFrom(c Canvas, origin Vector) {
+	for t := range w.window(origin) {
+		if w.View == nil || w.View.Visible(t) {
+			w.RelBlit(c, t.Offset.Sub(origin), Get(t, &Face{}))
+		} else if g, ok := w.View.Remembered(t); ok {
+			w.RelBlit(c, t.Offset.Sub(origin), Dim(g))
+		}
+	}
+}
+
+// Dim returns a Glyph with the same Ch but drawn in a subdued color.
+func Dim(g Glyph) Glyph {
+	return Glyph{Ch: g.Ch, Fg: ColorLightBlack, Bg: ColorBlack}
 }
@@ -55,3 +55,43 @@ func TestTilesWidget(t *testing.T) {
 		t.Error("TilesWidget.Draw produced incorrect buffer", c)
 	}
 }
+
+type MockView struct {
+	visible    map[*Tile]bool
+	remembered map[*Tile]Glyph
+}
+
+func (v MockView) Visible(t *Tile) bool {
+	return v.visible[t]
+}
+
+func (v MockView) Remembered(t *Tile) (Glyph, bool) {
+	g, ok := v.remembered[t]
+	return g, ok
+}
+
+func TestTilesWidget_View(t *testing.T) {
+	c := make(MockCanvas)
+	a := NewTile(Vec(0, 0))
+	b := NewTile(Vec(1, 0))
+	d := NewTile(Vec(2, 0))
+	PlaceMob(NewMob(Ch('@')), a)
+	PlaceMob(NewMob(Ch('D')), b)
+	b.Face = Ch('#')
+	view := MockView{
+		visible:    map[*Tile]bool{a: true},
+		remembered: map[*Tile]Glyph{a: Ch('.'), b: Ch('+')},
+	}
+	w := NewTilesWidget(Vec(0, 0), Vec(3, 1), []*Tile{a, b, d})
+	w.View = view
+	w.Draw(c)
+	if len(c) != 2 {
+		t.Error("TilesWidget.Draw drew unseen Tile", c)
+	}
+	if c[Vec(0, 0)] != Ch('@') {
+		t.Error("TilesWidget.Draw failed to draw visible Tile", c)
+	}
+	if c[Vec(1, 0)] != Dim(Ch('+')) {
+		t.Error("TilesWidget.Draw failed to draw remembered Tile", c)
+	}
+}
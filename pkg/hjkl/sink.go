@@ -0,0 +1,37 @@
+package hjkl
+
+// Sink receives Event published for a Level as a whole, such as the outcomes
+// of combat, so that the UI can report them.
+type Sink interface {
+	Publish(Event)
+}
+
+// SinkFunc is a function which acts as a Sink.
+type SinkFunc func(Event)
+
+// Publish calls the underlying function.
+func (f SinkFunc) Publish(v Event) {
+	f(v)
+}
+
+// Sinks is a Sink which publishes each Event to every constituent Sink, in
+// order.
+type Sinks []Sink
+
+// Publish publishes the Event to each Sink.
+func (s Sinks) Publish(v Event) {
+	for _, sink := range s {
+		sink.Publish(v)
+	}
+}
+
+// Discard is a Sink which ignores every Event.
+var Discard Sink = SinkFunc(func(Event) {})
+
+// Publish publishes an Event to the Events of the Level the Mob is on. If the
+// Mob is not on a Tile in a Level, the Event is discarded.
+func (e *Mob) Publish(v Event) {
+	if e.Pos != nil {
+		e.Pos.level.Publish(v)
+	}
+}
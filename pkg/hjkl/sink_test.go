@@ -0,0 +1,33 @@
+package hjkl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPublish(t *testing.T) {
+	level := NewLevel(3, 3, NewTile)
+	m := NewMob(Ch('@'))
+	PlaceMob(m, level.At(Vec(1, 1)))
+	m.Publish("discarded")
+
+	var got []Event
+	record := func(name string) Sink {
+		return SinkFunc(func(v Event) {
+			got = append(got, name, v)
+		})
+	}
+	level.Events = Sinks{record("a"), record("b")}
+	m.Publish("x")
+	m.Publish(&Move{Delta: Vec(1, 0)})
+
+	// Neither a Mob off the Level nor a nil Level has anywhere to publish.
+	NewMob(Ch('m')).Publish("lost")
+	var nowhere *Level
+	nowhere.Publish("lost")
+
+	want := []Event{"a", "x", "b", "x", "a", &Move{Delta: Vec(1, 0)}, "b", &Move{Delta: Vec(1, 0)}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Publish gave %v instead of %v", got, want)
+	}
+}
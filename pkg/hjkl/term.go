@@ -11,14 +11,16 @@ type Canvas interface {
 	Blit(Vector, Glyph)
 }
 
-// Terminal provides I/O capabilities.
+// Terminal provides I/O capabilities. Once a Terminal is resized, Input should
+// give an InputResize with the new size, which Size reports from then on.
 type Terminal interface {
 	Canvas
 
 	Init() error
-	Input() chan Key
+	Input() chan Input
 	Done()
 
+	Size() Vector
 	Clear()
 	Flush() error
 }
@@ -38,7 +40,7 @@ func (TermboxTerminal) Init() error {
 	if err := termbox.Init(); err != nil {
 		return err
 	}
-	if mode := termbox.SetInputMode(termbox.InputEsc); mode != termbox.InputEsc {
+	if mode := termbox.SetInputMode(termboxInputMode); mode != termboxInputMode {
 		return errors.New("could not set input mode")
 	}
 	if mode := termbox.SetOutputMode(termbox.Output256); mode != termbox.Output256 {
@@ -47,9 +49,15 @@ func (TermboxTerminal) Init() error {
 	return nil
 }
 
+// termboxInputMode is the termbox input mode used by TermboxTerminal. InputAlt
+// would report Esc followed by a key with ModAlt, but termbox then holds a lone
+// Esc as the prefix of the next key, so InputEsc is used instead and termbox
+// never reports ModAlt. Alt is only decoded by ANSITerminal.
+const termboxInputMode = termbox.InputEsc | termbox.InputMouse
+
 // Input gets an input channel and starts a goroutine to fill it.
-func (TermboxTerminal) Input() chan Key {
-	input := make(chan Key)
+func (TermboxTerminal) Input() chan Input {
+	input := make(chan Input)
 	go func() {
 		defer close(input)
 		for {
@@ -58,21 +66,51 @@ func (TermboxTerminal) Input() chan Key {
 				// Interupt is called by Done.
 				return
 			case termbox.EventKey:
-				// Unlike termbox, hjkl makes no distinctio between character
-				// keys and other special keys, so union the two.
-				input <- Key(event.Ch) | Key(event.Key)
+				input <- termboxKeyInput(event)
+			case termbox.EventMouse:
+				input <- Input{
+					Kind:  InputMouse,
+					Mouse: termboxMouseButtons[event.Key],
+					Pos:   Vec(event.MouseX, event.MouseY),
+				}
+			case termbox.EventResize:
+				input <- Input{Kind: InputResize, Pos: Vec(event.Width, event.Height)}
 			}
 		}
 	}()
 	return input
 }
 
+// termboxMouseButtons maps termbox mouse keys to MouseButton.
+var termboxMouseButtons = map[termbox.Key]MouseButton{
+	termbox.MouseLeft:      MouseLeft,
+	termbox.MouseMiddle:    MouseMiddle,
+	termbox.MouseRight:     MouseRight,
+	termbox.MouseRelease:   MouseRelease,
+	termbox.MouseWheelUp:   MouseWheelUp,
+	termbox.MouseWheelDown: MouseWheelDown,
+}
+
+// termboxKeyInput converts a termbox key event to an Input.
+func termboxKeyInput(event termbox.Event) Input {
+	// Unlike termbox, hjkl makes no distinction between character keys and
+	// other special keys in the Key code, so union the two.
+	in := KeyPress(Key(event.Ch) | Key(event.Key))
+	in.Mod = controlMod(in.Key)
+	return in
+}
+
 // Done interupts the input goroutine and closes termbox.
 func (TermboxTerminal) Done() {
 	termbox.Interrupt()
 	termbox.Close()
 }
 
+// Size gets the size of the termbox cell buffer.
+func (TermboxTerminal) Size() Vector {
+	return Vec(termbox.Size())
+}
+
 // Clear clears the termbox cell buffer.
 func (TermboxTerminal) Clear() {
 	// Use CellBuffer instead of termbox.Clear to avoid extra Flush.
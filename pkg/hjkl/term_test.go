@@ -0,0 +1,32 @@
+package hjkl
+
+import (
+	"testing"
+
+	"github.com/nsf/termbox-go"
+)
+
+func TestTermboxInputMode(t *testing.T) {
+	// InputAlt would stop termbox from reporting a lone Esc as KeyEsc.
+	if termboxInputMode&termbox.InputEsc == 0 || termboxInputMode&termbox.InputAlt != 0 {
+		t.Error("TermboxTerminal does not use InputEsc")
+	}
+}
+
+func TestTermboxKeyInput(t *testing.T) {
+	cases := []struct {
+		Event termbox.Event
+		Key   Key
+		Mod   Mod
+	}{
+		{termbox.Event{Type: termbox.EventKey, Ch: 'a'}, 'a', 0},
+		{termbox.Event{Type: termbox.EventKey, Key: termbox.KeyCtrlC}, KeyCtrlC, ModCtrl},
+		{termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEsc}, KeyEsc, 0},
+	}
+	for i, c := range cases {
+		in := termboxKeyInput(c.Event)
+		if in.Kind != InputKey || in.Key != c.Key || in.Mod != c.Mod {
+			t.Errorf("termboxKeyInput case %d gave %v", i, in)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package hjkl
+
+import "syscall"
+
+// Linux ioctl requests for getting and setting termios.
+const (
+	ioctlGetTermios = syscall.TCGETS
+	ioctlSetTermios = syscall.TCSETS
+)
@@ -0,0 +1,32 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package hjkl
+
+import (
+	"errors"
+	"os"
+)
+
+// errNoRaw is given on platforms without termios support.
+var errNoRaw = errors.New("raw terminal mode is unsupported on this platform")
+
+// rawState is empty on platforms without termios support.
+type rawState struct{}
+
+// enableRaw always fails on platforms without termios support.
+func enableRaw(uintptr) (*rawState, error) {
+	return nil, errNoRaw
+}
+
+// disableRaw always fails on platforms without termios support.
+func disableRaw(uintptr, *rawState) error {
+	return errNoRaw
+}
+
+// termSize always fails on platforms without termios support.
+func termSize(uintptr) (Vector, error) {
+	return Vector{}, errNoRaw
+}
+
+// notifyResize does nothing on platforms without termios support.
+func notifyResize(chan<- os.Signal) {}
@@ -0,0 +1,69 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package hjkl
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"unsafe"
+)
+
+// rawState stores the terminal settings to restore after raw mode.
+type rawState struct {
+	termios syscall.Termios
+}
+
+// ioctl performs an ioctl syscall with a pointer argument.
+func ioctl(fd, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// enableRaw puts the terminal into raw mode, returning the previous state.
+// Reads are given a 100ms timeout so input polling can be interrupted.
+func enableRaw(fd uintptr) (*rawState, error) {
+	var old rawState
+	if err := ioctl(fd, ioctlGetTermios, unsafe.Pointer(&old.termios)); err != nil {
+		return nil, err
+	}
+
+	// Equivalent to cfmakeraw, except for the read timeout.
+	raw := old.termios
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = 1
+
+	if err := ioctl(fd, ioctlSetTermios, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+	return &old, nil
+}
+
+// disableRaw restores the terminal to the state from before enableRaw.
+func disableRaw(fd uintptr, s *rawState) error {
+	return ioctl(fd, ioctlSetTermios, unsafe.Pointer(&s.termios))
+}
+
+// termSize gets the size of the terminal in cells.
+func termSize(fd uintptr) (Vector, error) {
+	var ws struct {
+		Row, Col, Xpixel, Ypixel uint16
+	}
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		return Vector{}, err
+	}
+	return Vec(int(ws.Col), int(ws.Row)), nil
+}
+
+// notifyResize relays SIGWINCH, which signals the terminal was resized.
+func notifyResize(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGWINCH)
+}
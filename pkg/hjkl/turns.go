@@ -0,0 +1,93 @@
+package hjkl
+
+import "github.com/jefflund/stones/pkg/hjkl/clock"
+
+// NormalSpeed is the Speed of an ordinary Mob, which waits exactly the cost of
+// each action before acting again.
+const NormalSpeed = 100
+
+// The time cost of each kind of action at NormalSpeed.
+const (
+	CostMove   = 100
+	CostAttack = 100
+	CostWait   = 100
+)
+
+// Speed is an Event which gets the speed of a Mob. Faster Mob wait less time
+// after each action, in proportion to NormalSpeed.
+type Speed struct {
+	Field[int]
+}
+
+// Delay gets the time a Mob waits after an action with the given cost, scaled
+// by the Speed of the Mob. Mob which do not handle Speed act at NormalSpeed.
+// The delay is always at least one, so time passes even for very fast Mob.
+func Delay(m *Mob, cost int) int {
+	speed := Get(m, &Speed{Field[int]{NormalSpeed}})
+	if speed <= 0 {
+		speed = NormalSpeed
+	}
+	return max(1, cost*NormalSpeed/speed)
+}
+
+// Turns decides whose turn it is, using a Clock to order the Mob by when they
+// next act. Each Mob, including the Player, takes a turn when it is popped from
+// the Clock, and the turn lasts until End is called with the cost of whatever
+// the Mob did. The world therefore waits on the Player whenever it is their
+// turn, no matter how long it takes to get input.
+//
+// The current Mob stays scheduled with zero delta until its turn ends, so the
+// Clock may be saved at any point without losing whose turn it is.
+type Turns struct {
+	Clock   *clock.Clock[*Mob]
+	Player  *Mob
+	current *Mob
+}
+
+// NewTurns creates a Turns using the given Clock.
+func NewTurns(c *clock.Clock[*Mob], player *Mob) *Turns {
+	return &Turns{Clock: c, Player: player}
+}
+
+// Next gets the Mob whose turn it is, advancing the Clock if needed. If the
+// Player is no longer scheduled, such as after dying, Next returns nil since
+// no Mob will ever wait on the Player again.
+func (t *Turns) Next() *Mob {
+	if t.current != nil {
+		if t.Clock.Contains(t.current) {
+			return t.current
+		}
+		// The current Mob was unscheduled, likely by dying, during its turn.
+		t.current = nil
+	}
+
+	if !t.Clock.Contains(t.Player) {
+		return nil
+	}
+	// Every due Mob acts before time moves on, one turn at a time.
+	due, _ := t.Clock.Advance()
+	for _, m := range due {
+		t.Clock.Schedule(m, 0)
+	}
+	t.current = due[0]
+	return t.current
+}
+
+// End ends the turn of the Mob after an action with the given cost, and
+// schedules its next turn after the Delay.
+func (t *Turns) End(m *Mob, cost int) {
+	if m == t.current {
+		t.current = nil
+	}
+	t.Clock.Schedule(m, Delay(m, cost))
+}
+
+// PlayerTurn determines whether it is the Player's turn, which is from when
+// Next gives the Player until their turn ends. Unlike Next, PlayerTurn never
+// advances the Clock, so it is safe to call just to check.
+func (t *Turns) PlayerTurn() bool {
+	if t.Player == nil || t.current != t.Player {
+		return false
+	}
+	return t.Clock.Contains(t.Player)
+}
@@ -0,0 +1,99 @@
+package hjkl
+
+import (
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl/clock"
+)
+
+// SpeedMob creates a Mob which handles Speed with the given speed.
+func SpeedMob(speed int) *Mob {
+	m := NewMob(Ch('m'))
+	m.Components.Add(Handler(func(e *Mob, v *Speed) {
+		v.Value = speed
+	}))
+	return m
+}
+
+func TestDelay(t *testing.T) {
+	cases := []struct {
+		Mob  *Mob
+		Cost int
+		Want int
+	}{
+		{NewMob(Ch('m')), CostMove, CostMove},
+		{SpeedMob(NormalSpeed), CostWait, CostWait},
+		{SpeedMob(2 * NormalSpeed), CostMove, CostMove / 2},
+		{SpeedMob(NormalSpeed / 2), CostAttack, CostAttack * 2},
+		{SpeedMob(0), CostMove, CostMove},
+		{SpeedMob(1000 * NormalSpeed), CostMove, 1},
+	}
+	for i, c := range cases {
+		if got := Delay(c.Mob, c.Cost); got != c.Want {
+			t.Errorf("Delay case %d gave %d instead of %d", i, got, c.Want)
+		}
+	}
+}
+
+func TestTurns(t *testing.T) {
+	player := NewMob(Ch('@'))
+	fast, slow := SpeedMob(2*NormalSpeed), SpeedMob(NormalSpeed/2)
+
+	c := clock.New[*Mob]()
+	c.Schedule(player, 1)
+	c.Schedule(fast, 1)
+	c.Schedule(slow, 1)
+	turns := NewTurns(c, player)
+
+	// Checking whose turn it is leaves the Clock alone.
+	if turns.PlayerTurn() || turns.PlayerTurn() || c.Now() != 0 {
+		t.Fatal("PlayerTurn advanced the Clock")
+	}
+
+	counts := make(map[*Mob]int)
+	for counts[player] < 8 {
+		m := turns.Next()
+		if m == player {
+			// The world waits on the Player until their turn ends.
+			if turns.Next() != player || !turns.PlayerTurn() {
+				t.Fatal("Turns failed to wait on the Player")
+			}
+			if delta, ok := c.Delta(player); !ok || delta != 0 {
+				t.Fatal("Turns unscheduled the Player during their turn")
+			}
+		}
+		counts[m]++
+		turns.End(m, CostMove)
+	}
+
+	// The Player and fast Mob tie on the last turn, but the Player was
+	// scheduled first and so goes first.
+	if counts[fast] != 14 {
+		t.Errorf("Fast Mob took %d turns instead of 14", counts[fast])
+	}
+	if counts[slow] != 4 {
+		t.Errorf("Slow Mob took %d turns instead of 4", counts[slow])
+	}
+}
+
+func TestTurns_Unscheduled(t *testing.T) {
+	player, mob := NewMob(Ch('@')), NewMob(Ch('m'))
+	c := clock.New[*Mob]()
+	c.Schedule(mob, 1)
+	c.Schedule(player, 2)
+	turns := NewTurns(c, player)
+
+	if turns.Next() != mob {
+		t.Fatal("Turns gave incorrect Mob")
+	}
+	// The Mob dies during its turn, so it never ends.
+	c.Unschedule(mob)
+	if turns.Next() != player {
+		t.Error("Turns failed to skip unscheduled Mob")
+	}
+
+	c.Unschedule(player)
+	if turns.Next() != nil || turns.PlayerTurn() {
+		t.Error("Turns gave a Mob without the Player scheduled")
+	}
+}
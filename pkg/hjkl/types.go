@@ -42,9 +42,38 @@ type Key rune
 
 // Key constants which normally require escapes.
 const (
-	KeyEsc   Key = 0x1B
-	KeyEnter Key = 0x0D
-	KeyCtrlC Key = 0x03
+	KeyEsc       Key = 0x1B
+	KeyEnter     Key = 0x0D
+	KeyCtrlC     Key = 0x03
+	KeyTab       Key = 0x09
+	KeyBackspace Key = 0x7F
+)
+
+// Key constants for special keys. The values match those used by termbox so
+// that every Terminal reports special keys identically.
+const (
+	KeyF1 Key = 0xFFFF - iota
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+	KeyInsert
+	KeyDelete
+	KeyHome
+	KeyEnd
+	KeyPgup
+	KeyPgdn
+	KeyArrowUp
+	KeyArrowDown
+	KeyArrowLeft
+	KeyArrowRight
 )
 
 // VIKeys is a mapping of VI Key to CompassDirs.
@@ -82,6 +111,30 @@ const (
 	ColorLightWhite
 )
 
+// RGB gets the 24-bit color of the Color using the standard xterm palette.
+func (c Color) RGB() (r, g, b uint8) {
+	switch {
+	case c < 16:
+		// The 16 system colors, with the bright variants at 8-15.
+		system := [16][3]uint8{
+			{0, 0, 0}, {128, 0, 0}, {0, 128, 0}, {128, 128, 0},
+			{0, 0, 128}, {128, 0, 128}, {0, 128, 128}, {192, 192, 192},
+			{128, 128, 128}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+			{0, 0, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+		}
+		return system[c][0], system[c][1], system[c][2]
+	case c < 232:
+		// The 6x6x6 color cube.
+		levels := [6]uint8{0, 95, 135, 175, 215, 255}
+		i := c - 16
+		return levels[i/36], levels[i/6%6], levels[i%6]
+	default:
+		// The 24 step grayscale ramp.
+		v := 8 + 10*uint8(c-232)
+		return v, v, v
+	}
+}
+
 // Glyph represents a single onscreen character.
 type Glyph struct {
 	Ch rune
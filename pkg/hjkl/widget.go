@@ -0,0 +1,240 @@
+package hjkl
+
+import "strconv"
+
+// Align describes the horizontal alignment of text within a Window.
+type Align uint8
+
+// Align constants for use with TextWidget.
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// offset gets the x offset needed to align a line of width n in a Window of
+// the given width.
+func (a Align) offset(n, width int) int {
+	switch a {
+	case AlignCenter:
+		return (width - n) / 2
+	case AlignRight:
+		return width - n
+	default:
+		return 0
+	}
+}
+
+// Span is a run of text drawn with a single color.
+type Span struct {
+	Text string
+	Fg   Color
+	Bg   Color
+}
+
+// Text is shorthand for Span{Text: s, Fg: ColorWhite, Bg: ColorBlack}.
+func Text(s string) Span {
+	return Span{s, ColorWhite, ColorBlack}
+}
+
+// TextFg is shorthand for Span{Text: s, Fg: fg, Bg: ColorBlack}.
+func TextFg(s string, fg Color) Span {
+	return Span{s, fg, ColorBlack}
+}
+
+// TextWidget is a Widget which draws a label made of colored Span. Newlines in
+// the Span text start a new line, and each line is aligned separately.
+type TextWidget struct {
+	Window
+	Spans []Span
+	Align Align
+}
+
+// NewTextWidget creates a TextWidget with the given Span.
+func NewTextWidget(pos, size Vector, spans ...Span) *TextWidget {
+	return &TextWidget{Window: Window{pos, size}, Spans: spans}
+}
+
+// SetText replaces the Span with the given Span.
+func (w *TextWidget) SetText(spans ...Span) {
+	w.Spans = spans
+}
+
+// Draw draws each line of text.
+func (w *TextWidget) Draw(c Canvas) {
+	lines := [][]Glyph{nil}
+	for _, s := range w.Spans {
+		for _, ch := range s.Text {
+			if ch == '\n' {
+				lines = append(lines, nil)
+				continue
+			}
+			lines[len(lines)-1] = append(lines[len(lines)-1], Glyph{ch, s.Fg, s.Bg})
+		}
+	}
+	for y, line := range lines {
+		x0 := w.Align.offset(len(line), w.Size.X)
+		for x, g := range line {
+			w.RelBlit(c, Vec(x0+x, y), g)
+		}
+	}
+}
+
+// PanelWidget is a Widget which draws a border with a title around an
+// optional Content Widget. The Content is placed inside the border.
+type PanelWidget struct {
+	Window
+	Title   string
+	Fg      Color
+	Content Placeable
+}
+
+// NewPanelWidget creates a PanelWidget with the given title and Content.
+func NewPanelWidget(pos, size Vector, title string, content Placeable) *PanelWidget {
+	w := &PanelWidget{Title: title, Fg: ColorWhite, Content: content}
+	w.Place(pos, size)
+	return w
+}
+
+// Place moves and resizes the PanelWidget, and places the Content inside the
+// border.
+func (w *PanelWidget) Place(pos, size Vector) {
+	w.Pos, w.Size = pos, size
+	if w.Content != nil {
+		w.Content.Place(pos.Add(Vec(1, 1)), Vec(max(0, size.X-2), max(0, size.Y-2)))
+	}
+}
+
+// Draw draws the border and title, followed by the Content.
+func (w *PanelWidget) Draw(c Canvas) {
+	if w.Size.X < 2 || w.Size.Y < 2 {
+		return
+	}
+	right, bottom := w.Size.X-1, w.Size.Y-1
+	for x := 1; x < right; x++ {
+		w.RelBlit(c, Vec(x, 0), ChFg('─', w.Fg))
+		w.RelBlit(c, Vec(x, bottom), ChFg('─', w.Fg))
+	}
+	for y := 1; y < bottom; y++ {
+		w.RelBlit(c, Vec(0, y), ChFg('│', w.Fg))
+		w.RelBlit(c, Vec(right, y), ChFg('│', w.Fg))
+	}
+	w.RelBlit(c, Vec(0, 0), ChFg('┌', w.Fg))
+	w.RelBlit(c, Vec(right, 0), ChFg('┐', w.Fg))
+	w.RelBlit(c, Vec(0, bottom), ChFg('└', w.Fg))
+	w.RelBlit(c, Vec(right, bottom), ChFg('┘', w.Fg))
+
+	// The title is clipped so it never overwrites the corners.
+	if w.Title != "" {
+		for i, ch := range []rune(" " + w.Title + " ") {
+			if x := i + 1; x < right {
+				w.RelBlit(c, Vec(x, 0), ChFg(ch, w.Fg))
+			}
+		}
+	}
+
+	if w.Content != nil {
+		w.Content.Draw(c)
+	}
+}
+
+// BarWidget is a Widget which draws a horizontal gauge showing Value out of
+// Max. The Label, followed by Value/Max, is centered over the gauge.
+type BarWidget struct {
+	Window
+	Value int
+	Max   int
+	Label string
+
+	// Full and Empty are the background colors of the filled and unfilled
+	// portions of the gauge.
+	Full  Color
+	Empty Color
+}
+
+// NewBarWidget creates a BarWidget with the given Label and colors.
+func NewBarWidget(pos, size Vector, label string, full, empty Color) *BarWidget {
+	return &BarWidget{Window: Window{pos, size}, Label: label, Full: full, Empty: empty}
+}
+
+// Set sets the Value and Max of the gauge.
+func (w *BarWidget) Set(value, max int) {
+	w.Value, w.Max = value, max
+}
+
+// Filled gets the number of filled cells in the gauge, rounding to nearest.
+func (w *BarWidget) Filled() int {
+	if w.Max <= 0 {
+		return 0
+	}
+	value := max(0, min(w.Max, w.Value))
+	return (2*value*w.Size.X + w.Max) / (2 * w.Max)
+}
+
+// Draw draws the gauge along with the Label and the values it shows.
+func (w *BarWidget) Draw(c Canvas) {
+	text := []rune(w.Label + strconv.Itoa(w.Value) + "/" + strconv.Itoa(w.Max))
+	x0 := AlignCenter.offset(len(text), w.Size.X)
+	filled := w.Filled()
+	for x := 0; x < w.Size.X; x++ {
+		g := Glyph{Ch: ' ', Fg: ColorLightWhite, Bg: w.Empty}
+		if x < filled {
+			g.Bg = w.Full
+		}
+		if i := x - x0; 0 <= i && i < len(text) {
+			g.Ch = text[i]
+		}
+		w.RelBlit(c, Vec(x, 0), g)
+	}
+}
+
+// ListWidget is a Widget which draws a list of items with a selection cursor,
+// scrolling as needed to keep the Selected item visible.
+type ListWidget struct {
+	Window
+	Items    []string
+	Selected int
+
+	// Top is the index of the first visible item.
+	Top int
+}
+
+// NewListWidget creates a ListWidget with the given items.
+func NewListWidget(pos, size Vector, items []string) *ListWidget {
+	return &ListWidget{Window: Window{pos, size}, Items: items}
+}
+
+// Select moves the selection cursor by the given amount, stopping at the
+// first and last items.
+func (w *ListWidget) Select(delta int) {
+	w.Selected = max(0, min(len(w.Items)-1, w.Selected+delta))
+}
+
+// Draw draws the visible items, with the Selected item highlighted.
+func (w *ListWidget) Draw(c Canvas) {
+	if w.Size.Y <= 0 {
+		return
+	}
+	if w.Selected < w.Top {
+		w.Top = w.Selected
+	} else if w.Selected >= w.Top+w.Size.Y {
+		w.Top = w.Selected - w.Size.Y + 1
+	}
+	w.Top = max(0, w.Top)
+
+	for y := 0; y < w.Size.Y && w.Top+y < len(w.Items); y++ {
+		i := w.Top + y
+		style := Ch(' ')
+		if i == w.Selected {
+			style = Glyph{Ch: ' ', Fg: ColorBlack, Bg: ColorWhite}
+		}
+		text := []rune(w.Items[i])
+		for x := 0; x < w.Size.X; x++ {
+			g := style
+			if x < len(text) {
+				g.Ch = text[x]
+			}
+			w.RelBlit(c, Vec(x, y), g)
+		}
+	}
+}
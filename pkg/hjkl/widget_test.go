@@ -0,0 +1,111 @@
+package hjkl
+
+import "testing"
+
+// Chars gets the characters drawn on a MockCanvas as rows of text.
+func (c MockCanvas) Chars(size Vector) []string {
+	rows := make([]string, size.Y)
+	for y := range rows {
+		row := make([]rune, size.X)
+		for x := range row {
+			row[x] = ' '
+			if g, ok := c[Vec(x, y)]; ok && g.Ch != 0 {
+				row[x] = g.Ch
+			}
+		}
+		rows[y] = string(row)
+	}
+	return rows
+}
+
+func ExpectChars(t *testing.T, c MockCanvas, expected []string) {
+	t.Helper()
+	got := c.Chars(Vec(len([]rune(expected[0])), len(expected)))
+	for y := range expected {
+		if got[y] != expected[y] {
+			t.Errorf("Draw produced incorrect buffer:\n%q\nwant:\n%q", got, expected)
+			return
+		}
+	}
+}
+
+func TestTextWidget(t *testing.T) {
+	cases := []struct {
+		Align    Align
+		Expected []string
+	}{
+		{AlignLeft, []string{"HP: 5/10  ", "ok        "}},
+		{AlignCenter, []string{" HP: 5/10 ", "    ok    "}},
+		{AlignRight, []string{"  HP: 5/10", "        ok"}},
+	}
+	for _, tc := range cases {
+		c := make(MockCanvas)
+		w := NewTextWidget(Vec(0, 0), Vec(10, 2), Text("HP: "), TextFg("5", ColorRed), Text("/10\nok"))
+		w.Align = tc.Align
+		w.Draw(c)
+		ExpectChars(t, c, tc.Expected)
+	}
+
+	c := make(MockCanvas)
+	NewTextWidget(Vec(1, 0), Vec(3, 1), TextFg("abcdef", ColorRed)).Draw(c)
+	ExpectChars(t, c, []string{" abc  "})
+	if c[Vec(2, 0)] != ChFg('b', ColorRed) {
+		t.Error("TextWidget drew incorrect color", c[Vec(2, 0)])
+	}
+}
+
+func TestPanelWidget(t *testing.T) {
+	c := make(MockCanvas)
+	text := NewTextWidget(Vector{}, Vector{}, Text("inside text"))
+	w := NewPanelWidget(Vec(1, 0), Vec(8, 4), "Stats", text)
+	w.Draw(c)
+	ExpectChars(t, c, []string{
+		" ┌ Stats┐ ",
+		" │inside│ ",
+		" │      │ ",
+		" └──────┘ ",
+	})
+	if text.Pos != Vec(2, 1) || text.Size != Vec(6, 2) {
+		t.Errorf("PanelWidget placed Content at %v with size %v", text.Pos, text.Size)
+	}
+}
+
+func TestBarWidget(t *testing.T) {
+	c := make(MockCanvas)
+	w := NewBarWidget(Vec(0, 0), Vec(10, 1), "HP ", ColorRed, ColorBlue)
+	w.Set(3, 10)
+	w.Draw(c)
+	ExpectChars(t, c, []string{" HP 3/10  "})
+	if w.Filled() != 3 || c[Vec(2, 0)].Bg != ColorRed || c[Vec(3, 0)].Bg != ColorBlue {
+		t.Error("BarWidget drew incorrect gauge", c)
+	}
+
+	for _, tc := range []struct{ Value, Max, Filled int }{
+		{0, 10, 0}, {10, 10, 10}, {15, 10, 10}, {-1, 10, 0}, {1, 3, 3}, {5, 0, 0},
+	} {
+		w.Set(tc.Value, tc.Max)
+		if got := w.Filled(); got != tc.Filled {
+			t.Errorf("BarWidget.Filled() with %d/%d = %d", tc.Value, tc.Max, got)
+		}
+	}
+}
+
+func TestListWidget(t *testing.T) {
+	w := NewListWidget(Vec(0, 0), Vec(5, 2), []string{"one", "two", "three", "four"})
+	w.Select(2)
+	c := make(MockCanvas)
+	w.Draw(c)
+	ExpectChars(t, c, []string{"two  ", "three"})
+	if c[Vec(0, 1)].Bg != ColorWhite || c[Vec(0, 0)].Bg != ColorBlack {
+		t.Error("ListWidget drew incorrect selection", c)
+	}
+
+	w.Select(10)
+	w.Select(-3)
+	c = make(MockCanvas)
+	w.Draw(c)
+	if w.Selected != 0 || w.Top != 0 {
+		t.Errorf("ListWidget.Select gave Selected %d and Top %d", w.Selected, w.Top)
+	}
+	ExpectChars(t, c, []string{"one  ", "two  "})
+}
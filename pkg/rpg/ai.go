@@ -0,0 +1,241 @@
+package rpg
+
+import (
+	"math"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/btree"
+	"github.com/jefflund/stones/pkg/hjkl/fov"
+	"github.com/jefflund/stones/pkg/hjkl/path"
+	"github.com/jefflund/stones/pkg/hjkl/rand"
+)
+
+// TakeTurn is an Event sent to a Mob when its turn comes up. The Mob acts, then
+// sets Cost to the time it spent, leaving it zero if it did nothing.
+type TakeTurn struct {
+	Pursuit *Pursuit
+	Cost    int
+}
+
+// Pursuit is shared by every Mob taking a turn, and caches the maps used to
+// chase and flee the Target until the Target moves.
+type Pursuit struct {
+	Target *hjkl.Mob
+	chase  path.DijkstraMap
+	flee   path.DijkstraMap
+}
+
+// Chase gets a map which leads downhill towards the Target.
+func (p *Pursuit) Chase() path.DijkstraMap {
+	if p.chase == nil && p.Target.Pos != nil {
+		p.chase = path.Dijkstra([]*hjkl.Tile{p.Target.Pos})
+	}
+	return p.chase
+}
+
+// Flee gets a map which leads downhill away from the Target.
+func (p *Pursuit) Flee() path.DijkstraMap {
+	if p.flee == nil && p.Target.Pos != nil {
+		p.flee = p.Chase().Flee(1.2)
+	}
+	return p.flee
+}
+
+// The Blackboard keys used by the behavior trees of every Brain. The actor and
+// the turn are set before running a tree, while the Target is chosen by the
+// tree itself.
+var (
+	ActorKey  = btree.NewKey[*hjkl.Mob]("actor")
+	TurnKey   = btree.NewKey[*TakeTurn]("turn")
+	TargetKey = btree.NewKey[*hjkl.Mob]("target")
+)
+
+// AI is a Component which runs the behavior tree of the named Brain each time
+// its Mob takes a turn. Every Mob with the same Brain shares one tree, so only
+// the name need be saved. The tree is reset every turn, so it decides afresh
+// what to do.
+type AI struct {
+	Brain string
+}
+
+// Brains maps the name of each Brain to its behavior tree. Each tree is shared
+// by every Mob with that Brain, so like any btree.Tree, the trees must only be
+// run from a single goroutine, and never from within a running tree.
+var Brains = map[string]*btree.Tree{
+	// A brute attacks anything it sees, and wanders otherwise.
+	"brute": btree.NewTree(func(t *btree.Tree) btree.Behavior {
+		return btree.Sequence(
+			FindTarget(t),
+			btree.Selection(
+				AttackAdjacent(t),
+				btree.Sequence(CanSeeTarget(t), MoveToward(t)),
+				Wander(t),
+			),
+		)
+	}),
+	// A coward is a brute which runs once badly hurt.
+	"coward": btree.NewTree(func(t *btree.Tree) btree.Behavior {
+		return btree.Sequence(
+			FindTarget(t),
+			btree.Selection(
+				FleeWhenHurt(t, 0.5),
+				AttackAdjacent(t),
+				btree.Sequence(CanSeeTarget(t), MoveToward(t)),
+				Wander(t),
+			),
+		)
+	}),
+	// A sentinel holds its ground, attacking whatever comes close.
+	"sentinel": btree.NewTree(func(t *btree.Tree) btree.Behavior {
+		return btree.Sequence(
+			FindTarget(t),
+			btree.Selection(
+				AttackAdjacent(t),
+				Wait(t),
+			),
+		)
+	}),
+}
+
+func (ai *AI) Handle(e *hjkl.Mob, v hjkl.Event) {
+	turn, ok := v.(*TakeTurn)
+	if !ok || e.Pos == nil {
+		return
+	}
+	tree, ok := Brains[ai.Brain]
+	if !ok {
+		tree = Brains["brute"]
+	}
+
+	var b btree.Blackboard
+	ActorKey.Set(&b, e)
+	TurnKey.Set(&b, turn)
+	tree.Run(&b)
+}
+
+// move moves the actor, and returns true if the actor did anything.
+func move(b *btree.Blackboard, delta hjkl.Vector) bool {
+	actor, _ := ActorKey.Get(b)
+	turn, _ := TurnKey.Get(b)
+	move := &hjkl.Move{Delta: delta}
+	actor.Handle(move)
+	turn.Cost += move.Cost
+	return move.Cost > 0
+}
+
+// FindTarget sets the Target to that of the Pursuit, and always succeeds. The
+// Target is left unset if there is no Pursuit, or its Target is not on a Tile.
+func FindTarget(t *btree.Tree) btree.Behavior {
+	return t.Func(func(b *btree.Blackboard) {
+		TargetKey.Delete(b)
+		turn, _ := TurnKey.Get(b)
+		if turn.Pursuit != nil && turn.Pursuit.Target.Pos != nil {
+			TargetKey.Set(b, turn.Pursuit.Target)
+		}
+	})
+}
+
+// CanSeeTarget succeeds if the actor has line of sight to the Target.
+func CanSeeTarget(t *btree.Tree) btree.Behavior {
+	return btree.With(t, TargetKey, func(b *btree.Blackboard, target *hjkl.Mob) btree.State {
+		actor, _ := ActorKey.Get(b)
+		if fov.LoS(actor.Pos, target.Pos) {
+			return btree.Success
+		}
+		return btree.Failure
+	})
+}
+
+// MoveToward moves the actor a step closer to the Target, failing if there is
+// no way closer.
+func MoveToward(t *btree.Tree) btree.Behavior {
+	return btree.With(t, TargetKey, func(b *btree.Blackboard, _ *hjkl.Mob) btree.State {
+		actor, _ := ActorKey.Get(b)
+		turn, _ := TurnKey.Get(b)
+		if dir, ok := turn.Pursuit.Chase().Downhill(actor.Pos); ok && move(b, dir) {
+			return btree.Success
+		}
+		return btree.Failure
+	})
+}
+
+// FleeFrom moves the actor a step further from the Target, preferring routes
+// which escape over those leading into dead ends. FleeFrom fails if the actor
+// is cornered.
+func FleeFrom(t *btree.Tree) btree.Behavior {
+	return btree.With(t, TargetKey, func(b *btree.Blackboard, _ *hjkl.Mob) btree.State {
+		actor, _ := ActorKey.Get(b)
+		turn, _ := TurnKey.Get(b)
+		chase, flee := turn.Pursuit.Chase(), turn.Pursuit.Flee()
+
+		// The Tile under the actor is occupied, so is missing from the maps.
+		here := math.MaxInt
+		for _, tile := range actor.Pos.Neighbors() {
+			if v, ok := chase[tile]; ok {
+				here = min(here, v+1)
+			}
+		}
+
+		best, found := math.MaxInt, false
+		var dir hjkl.Vector
+		for delta, tile := range actor.Pos.Neighbors() {
+			if v, ok := flee[tile]; ok && v < best && chase[tile] > here {
+				best, dir, found = v, delta, true
+			}
+		}
+		if found && move(b, dir) {
+			return btree.Success
+		}
+		return btree.Failure
+	})
+}
+
+// AttackAdjacent attacks the Target if it is next to the actor.
+func AttackAdjacent(t *btree.Tree) btree.Behavior {
+	return btree.With(t, TargetKey, func(b *btree.Blackboard, target *hjkl.Mob) btree.State {
+		actor, _ := ActorKey.Get(b)
+		for dir, tile := range actor.Pos.Neighbors() {
+			if tile == target.Pos && move(b, dir) {
+				return btree.Success
+			}
+		}
+		return btree.Failure
+	})
+}
+
+// Hurt succeeds if the actor has no more than the given fraction of its
+// maximum Health.
+func Hurt(t *btree.Tree, frac float64) btree.Behavior {
+	return btree.Check(t, ActorKey, func(actor *hjkl.Mob) bool {
+		for _, c := range actor.Components {
+			if c, ok := c.(*Character); ok {
+				return float64(c.Health) <= frac*float64(c.MaxHealth)
+			}
+		}
+		return false
+	})
+}
+
+// FleeWhenHurt flees from a visible Target once the actor is Hurt.
+func FleeWhenHurt(t *btree.Tree, frac float64) btree.Behavior {
+	return btree.Sequence(Hurt(t, frac), CanSeeTarget(t), FleeFrom(t))
+}
+
+// Wander moves the actor in a random direction. Wander always succeeds, even
+// if the actor walks into something, since it still wasted its turn.
+func Wander(t *btree.Tree) btree.Behavior {
+	return t.Func(func(b *btree.Blackboard) {
+		if !move(b, rand.Choice(hjkl.CompassDirs)) {
+			turn, _ := TurnKey.Get(b)
+			turn.Cost += hjkl.CostWait
+		}
+	})
+}
+
+// Wait passes the turn.
+func Wait(t *btree.Tree) btree.Behavior {
+	return t.Func(func(b *btree.Blackboard) {
+		turn, _ := TurnKey.Get(b)
+		turn.Cost += hjkl.CostWait
+	})
+}
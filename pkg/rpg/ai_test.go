@@ -0,0 +1,277 @@
+package rpg
+
+import (
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/btree"
+)
+
+// newTestLevel creates an open Level, except for walls at the given offsets.
+func newTestLevel(cols, rows int, walls ...hjkl.Vector) *hjkl.Level {
+	return hjkl.NewLevel(cols, rows, func(v hjkl.Vector) *hjkl.Tile {
+		t := hjkl.NewTile(v)
+		for _, w := range walls {
+			if v == w {
+				ForestFence(t)
+			}
+		}
+		return t
+	})
+}
+
+// newTestMob spawns a Mob with the given Health out of 10 which never evades
+// and always does 1 damage.
+func newTestMob(level *hjkl.Level, offset hjkl.Vector, health int) (*hjkl.Mob, *Character) {
+	m := hjkl.NewMob(hjkl.Ch('m'))
+	c := &Character{
+		Attributes: Attributes{MaxHealth: 10, Damage: 1},
+		Variables:  Variables{Health: health},
+	}
+	m.Components.Add(c)
+	level.Mobs.Spawn(m, level.At(offset))
+	return m, c
+}
+
+// runLeaf runs a tree made of a single leaf for the actor, with the target
+// already chosen if it is non-nil.
+func runLeaf(leaf func(*btree.Tree) btree.Behavior, actor, target *hjkl.Mob) (btree.State, *TakeTurn) {
+	turn := &TakeTurn{}
+	var b btree.Blackboard
+	ActorKey.Set(&b, actor)
+	TurnKey.Set(&b, turn)
+	if target != nil {
+		turn.Pursuit = &Pursuit{Target: target}
+		TargetKey.Set(&b, target)
+	}
+	return btree.NewTree(leaf).Run(&b), turn
+}
+
+// chebyshev gets the number of steps between two Mob.
+func chebyshev(a, b *hjkl.Mob) int {
+	d := a.Pos.Offset.Sub(b.Pos.Offset)
+	return max(d.X, -d.X, d.Y, -d.Y)
+}
+
+func TestFindTarget(t *testing.T) {
+	level := newTestLevel(5, 5)
+	actor, _ := newTestMob(level, hjkl.Vec(0, 0), 10)
+	target, _ := newTestMob(level, hjkl.Vec(4, 4), 10)
+
+	var b btree.Blackboard
+	ActorKey.Set(&b, actor)
+	TurnKey.Set(&b, &TakeTurn{Pursuit: &Pursuit{Target: target}})
+	tree := btree.NewTree(FindTarget)
+	if tree.Run(&b) != btree.Success {
+		t.Fatal("FindTarget failed")
+	}
+	if got, _ := TargetKey.Get(&b); got != target {
+		t.Error("FindTarget failed to set the Target")
+	}
+
+	TurnKey.Set(&b, &TakeTurn{})
+	if tree.Run(&b) != btree.Success {
+		t.Fatal("FindTarget failed without a Pursuit")
+	}
+	if _, ok := TargetKey.Get(&b); ok {
+		t.Error("FindTarget set a Target without a Pursuit")
+	}
+}
+
+func TestCanSeeTarget(t *testing.T) {
+	level := newTestLevel(5, 3, hjkl.Vec(2, 0), hjkl.Vec(2, 1), hjkl.Vec(2, 2))
+	actor, _ := newTestMob(level, hjkl.Vec(0, 1), 10)
+	near, _ := newTestMob(level, hjkl.Vec(1, 0), 10)
+	hidden, _ := newTestMob(level, hjkl.Vec(4, 1), 10)
+
+	if got, _ := runLeaf(CanSeeTarget, actor, near); got != btree.Success {
+		t.Error("CanSeeTarget failed to see a visible Target")
+	}
+	if got, _ := runLeaf(CanSeeTarget, actor, hidden); got != btree.Failure {
+		t.Error("CanSeeTarget saw through a wall")
+	}
+	if got, _ := runLeaf(CanSeeTarget, actor, nil); got != btree.Failure {
+		t.Error("CanSeeTarget succeeded without a Target")
+	}
+}
+
+func TestMoveToward(t *testing.T) {
+	level := newTestLevel(5, 3)
+	actor, _ := newTestMob(level, hjkl.Vec(0, 1), 10)
+	target, _ := newTestMob(level, hjkl.Vec(4, 1), 10)
+
+	got, turn := runLeaf(MoveToward, actor, target)
+	if got != btree.Success || turn.Cost != hjkl.CostMove {
+		t.Fatalf("MoveToward gave %v with Cost %d", got, turn.Cost)
+	}
+	if chebyshev(actor, target) != 3 {
+		t.Error("MoveToward failed to move closer")
+	}
+
+	if got, turn := runLeaf(MoveToward, actor, nil); got != btree.Failure || turn.Cost != 0 {
+		t.Error("MoveToward moved without a Target")
+	}
+}
+
+func TestFleeFrom(t *testing.T) {
+	level := newTestLevel(7, 5)
+	actor, _ := newTestMob(level, hjkl.Vec(3, 2), 10)
+	target, _ := newTestMob(level, hjkl.Vec(2, 2), 10)
+
+	got, turn := runLeaf(FleeFrom, actor, target)
+	if got != btree.Success || turn.Cost != hjkl.CostMove {
+		t.Fatalf("FleeFrom gave %v with Cost %d", got, turn.Cost)
+	}
+	if chebyshev(actor, target) != 2 {
+		t.Error("FleeFrom failed to move away")
+	}
+}
+
+func TestFleeFrom_Cornered(t *testing.T) {
+	level := newTestLevel(2, 2)
+	actor, _ := newTestMob(level, hjkl.Vec(0, 0), 10)
+	target, _ := newTestMob(level, hjkl.Vec(1, 1), 10)
+
+	got, turn := runLeaf(FleeFrom, actor, target)
+	if got != btree.Failure || turn.Cost != 0 {
+		t.Errorf("Cornered FleeFrom gave %v with Cost %d", got, turn.Cost)
+	}
+	if actor.Pos != level.At(hjkl.Vec(0, 0)) {
+		t.Error("Cornered FleeFrom moved the actor")
+	}
+}
+
+func TestAttackAdjacent(t *testing.T) {
+	level := newTestLevel(5, 3)
+	actor, _ := newTestMob(level, hjkl.Vec(1, 1), 10)
+	target, stats := newTestMob(level, hjkl.Vec(2, 2), 10)
+	far, _ := newTestMob(level, hjkl.Vec(4, 1), 10)
+
+	got, turn := runLeaf(AttackAdjacent, actor, target)
+	if got != btree.Success || turn.Cost != hjkl.CostAttack {
+		t.Fatalf("AttackAdjacent gave %v with Cost %d", got, turn.Cost)
+	}
+	if stats.Health != 9 {
+		t.Error("AttackAdjacent failed to damage the Target")
+	}
+
+	if got, turn := runLeaf(AttackAdjacent, actor, far); got != btree.Failure || turn.Cost != 0 {
+		t.Error("AttackAdjacent attacked a distant Target")
+	}
+}
+
+func TestHurt(t *testing.T) {
+	level := newTestLevel(3, 1)
+	cases := []struct {
+		Health int
+		Want   btree.State
+	}{
+		{10, btree.Failure},
+		{6, btree.Failure},
+		{5, btree.Success},
+		{1, btree.Success},
+	}
+	for _, c := range cases {
+		actor, _ := newTestMob(level, hjkl.Vec(0, 0), c.Health)
+		hurt := func(t *btree.Tree) btree.Behavior { return Hurt(t, 0.5) }
+		if got, _ := runLeaf(hurt, actor, nil); got != c.Want {
+			t.Errorf("Hurt with Health %d gave %v", c.Health, got)
+		}
+		level.Mobs.Remove(actor)
+	}
+
+	// A Mob without a Character is never hurt.
+	m := hjkl.NewMob(hjkl.Ch('m'))
+	level.Mobs.Spawn(m, level.At(hjkl.Vec(1, 0)))
+	hurt := func(t *btree.Tree) btree.Behavior { return Hurt(t, 1) }
+	if got, _ := runLeaf(hurt, m, nil); got != btree.Failure {
+		t.Error("Hurt succeeded without a Character")
+	}
+}
+
+func TestWander(t *testing.T) {
+	level := newTestLevel(3, 3)
+	actor, _ := newTestMob(level, hjkl.Vec(1, 1), 10)
+	got, turn := runLeaf(Wander, actor, nil)
+	if got != btree.Success || turn.Cost != hjkl.CostMove {
+		t.Errorf("Wander gave %v with Cost %d", got, turn.Cost)
+	}
+	if actor.Pos == level.At(hjkl.Vec(1, 1)) {
+		t.Error("Wander failed to move in the open")
+	}
+
+	// With nowhere to go, wandering still takes time.
+	level = newTestLevel(1, 1)
+	actor, _ = newTestMob(level, hjkl.Vec(0, 0), 10)
+	if got, turn := runLeaf(Wander, actor, nil); got != btree.Success || turn.Cost != hjkl.CostWait {
+		t.Errorf("Stuck Wander gave %v with Cost %d", got, turn.Cost)
+	}
+}
+
+func TestWait(t *testing.T) {
+	level := newTestLevel(3, 3)
+	actor, _ := newTestMob(level, hjkl.Vec(1, 1), 10)
+	got, turn := runLeaf(Wait, actor, nil)
+	if got != btree.Success || turn.Cost != hjkl.CostWait || actor.Pos != level.At(hjkl.Vec(1, 1)) {
+		t.Errorf("Wait gave %v with Cost %d", got, turn.Cost)
+	}
+}
+
+// takeTestTurn gives a Mob the named Brain, and has it take a turn pursuing
+// the target.
+func takeTestTurn(brain string, m, target *hjkl.Mob) *TakeTurn {
+	m.Components.Add(&AI{Brain: brain})
+	turn := &TakeTurn{Pursuit: &Pursuit{Target: target}}
+	m.Handle(turn)
+	return turn
+}
+
+func TestBrains(t *testing.T) {
+	cases := []struct {
+		Brain   string
+		Health  int
+		Start   hjkl.Vector
+		Attack  bool
+		Closer  bool
+		Farther bool
+	}{
+		{"brute", 10, hjkl.Vec(4, 2), true, false, false},
+		{"brute", 10, hjkl.Vec(6, 2), false, true, false},
+		{"brute", 1, hjkl.Vec(4, 2), true, false, false},
+		{"coward", 10, hjkl.Vec(4, 2), true, false, false},
+		{"coward", 10, hjkl.Vec(6, 2), false, true, false},
+		{"coward", 1, hjkl.Vec(4, 2), false, false, true},
+		{"sentinel", 10, hjkl.Vec(4, 2), true, false, false},
+		{"sentinel", 10, hjkl.Vec(6, 2), false, false, false},
+		{"unknown", 10, hjkl.Vec(6, 2), false, true, false},
+	}
+	for _, c := range cases {
+		level := newTestLevel(9, 5)
+		target, stats := newTestMob(level, hjkl.Vec(3, 2), 10)
+		m, _ := newTestMob(level, c.Start, c.Health)
+		before := chebyshev(m, target)
+
+		turn := takeTestTurn(c.Brain, m, target)
+		if turn.Cost == 0 {
+			t.Errorf("%s with Health %d at %v did nothing", c.Brain, c.Health, c.Start)
+		}
+		if attacked := stats.Health < 10; attacked != c.Attack {
+			t.Errorf("%s with Health %d at %v gave attack %v", c.Brain, c.Health, c.Start, attacked)
+		}
+		after := chebyshev(m, target)
+		if closer := after < before; closer != c.Closer {
+			t.Errorf("%s with Health %d at %v gave closer %v", c.Brain, c.Health, c.Start, closer)
+		}
+		if farther := after > before; farther != c.Farther {
+			t.Errorf("%s with Health %d at %v gave farther %v", c.Brain, c.Health, c.Start, farther)
+		}
+	}
+}
+
+func TestBestiary_Brains(t *testing.T) {
+	for _, b := range Bestiary {
+		if _, ok := Brains[b.Brain]; !ok {
+			t.Errorf("%s has unknown Brain %q", b.Name.Singular, b.Brain)
+		}
+	}
+}
@@ -1,67 +1,110 @@
 package rpg
 
-import "github.com/jefflund/stones/pkg/hjkl"
+import (
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/rand"
+)
 
 type BestiaryEntry struct {
+	Name       Name
 	Face       hjkl.Glyph
 	Attributes Attributes
+	Brain      string
+	Weight     float64
 }
 
 func (b BestiaryEntry) New() *hjkl.Mob {
 	m := hjkl.NewMob(b.Face)
+	name := b.Name
+	m.Components.Add(&name)
 	m.Components.Add(&Character{
 		Attributes: b.Attributes,
 		Variables: Variables{
 			Health: b.Attributes.MaxHealth,
 		},
 	})
+	if b.Brain != "" {
+		m.Components.Add(&AI{Brain: b.Brain})
+	}
 	return m
 }
 
 var Bestiary = []BestiaryEntry{
 	{
-		Face: hjkl.ChFg('U', hjkl.ColorRed),
+		Name:   Name{Singular: "bear", Plural: "bears"},
+		Face:   hjkl.ChFg('U', hjkl.ColorRed),
+		Brain:  "brute",
+		Weight: 1,
 		Attributes: Attributes{
 			MaxHealth: 10,
 			Damage:    3,
+			Speed:     50,
 		},
 	},
 	{
-		Face: hjkl.ChFg('u', hjkl.ColorRed),
+		Name:   Name{Singular: "boar", Plural: "boars"},
+		Face:   hjkl.ChFg('u', hjkl.ColorRed),
+		Brain:  "coward",
+		Weight: 3,
 		Attributes: Attributes{
 			MaxHealth: 3,
 			Damage:    1,
+			Speed:     75,
 		},
 	},
 	{
-		Face: hjkl.ChFg('u', hjkl.ColorLightRed),
+		Name:   Name{Singular: "warthog", Plural: "warthogs"},
+		Face:   hjkl.ChFg('u', hjkl.ColorLightRed),
+		Brain:  "brute",
+		Weight: 2,
 		Attributes: Attributes{
 			MaxHealth: 3,
 			Damage:    2,
+			Speed:     75,
 		},
 	},
 	{
-		Face: hjkl.ChFg('a', hjkl.ColorLightBlue),
+		Name:   Name{Singular: "ant", Plural: "ants"},
+		Face:   hjkl.ChFg('a', hjkl.ColorLightBlue),
+		Brain:  "coward",
+		Weight: 3,
 		Attributes: Attributes{
 			MaxHealth: 5,
 			Damage:    1,
+			Evasion:   0.25,
+			Speed:     hjkl.NormalSpeed,
 		},
 	},
 	{
-		Face: hjkl.ChFg('A', hjkl.ColorLightBlue),
+		Name:   Name{Singular: "ant queen", Plural: "ant queens", Pronoun: PronounShe},
+		Face:   hjkl.ChFg('A', hjkl.ColorLightBlue),
+		Brain:  "sentinel",
+		Weight: 1,
 		Attributes: Attributes{
 			MaxHealth: 20,
 			Damage:    1,
+			Speed:     25,
+			Venom:     2,
 		},
 	},
 }
 
+func SpawnTable(entries []BestiaryEntry) *rand.WeightedTable[BestiaryEntry] {
+	weights := make([]float64, len(entries))
+	for i, e := range entries {
+		weights[i] = e.Weight
+	}
+	return rand.NewWeightedTable(entries, weights)
+}
+
 func NewHero() *hjkl.Mob {
 	entry := BestiaryEntry{
+		Name: Name{Singular: "you", Plural: "you", Pronoun: PronounThey},
 		Face: hjkl.Ch('@'),
 		Attributes: Attributes{
 			MaxHealth: 10,
 			Damage:    2,
+			Speed:     hjkl.NormalSpeed,
 		},
 	}
 	return entry.New()
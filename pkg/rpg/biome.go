@@ -5,31 +5,64 @@ import (
 	"github.com/jefflund/stones/pkg/hjkl/rand"
 )
 
+// Terrain is a Component which names a Tile, so that hjkl.Describe has
+// something to say about it.
+type Terrain struct {
+	Name string
+}
+
+func (t *Terrain) Handle(e *hjkl.Tile, v hjkl.Event) {
+	if v, ok := v.(*hjkl.NameQuery); ok {
+		v.Value = t.Name
+	}
+}
+
+// SetTerrain names a Tile, replacing any existing Terrain.
+func SetTerrain(t *hjkl.Tile, name string) {
+	for _, c := range t.Components {
+		if c, ok := c.(*Terrain); ok {
+			c.Name = name
+			return
+		}
+	}
+	t.Components.Add(&Terrain{name})
+}
+
 func ForestTile(o hjkl.Vector) *hjkl.Tile {
 	t := hjkl.NewTile(o)
 	if rand.Chance(0.1) {
-		t.Face = rand.Choice([]hjkl.Glyph{
-			hjkl.ChFg('%', hjkl.ColorGreen),
+		t.Face = forestTrees.Draw()
+		t.Pass = false
+		SetTerrain(t, "tree")
+	} else {
+		t.Face = forestFloors.Draw()
+		SetTerrain(t, "grass")
+	}
+	return t
+}
+
+var (
+	forestTrees = rand.NewWeightedTable(
+		[]hjkl.Glyph{
 			hjkl.ChFg('%', hjkl.ColorGreen),
 			hjkl.ChFg('%', hjkl.ColorLightGreen),
 			hjkl.ChFg('%', hjkl.ColorLightYellow),
-		})
-		t.Pass = false
-	} else {
-		t.Face = rand.Choice([]hjkl.Glyph{
-			hjkl.ChFg('.', hjkl.ColorGreen),
+		},
+		[]float64{2, 1, 1},
+	)
+	forestFloors = rand.NewWeightedTable(
+		[]hjkl.Glyph{
 			hjkl.ChFg('.', hjkl.ColorGreen),
-			hjkl.ChFg('.', hjkl.ColorGreen),
-			hjkl.ChFg('.', hjkl.ColorLightGreen),
 			hjkl.ChFg('.', hjkl.ColorLightGreen),
 			hjkl.ChFg('.', hjkl.ColorLightYellow),
 			hjkl.ChFg('.', hjkl.ColorLightWhite),
-		})
-	}
-	return t
-}
+		},
+		[]float64{3, 2, 1, 1},
+	)
+)
 
 func ForestFence(t *hjkl.Tile) {
 	t.Face = hjkl.Ch('#')
 	t.Pass = false
+	SetTerrain(t, "wall")
 }
@@ -0,0 +1,30 @@
+package rpg
+
+import (
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+)
+
+func TestForestTile_Describe(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := range 100 {
+		tile := ForestTile(hjkl.Vec(i, 0))
+		want := "You see the grass."
+		if !tile.Pass {
+			want = "You see the tree."
+		}
+		if got := hjkl.Describe(tile); got != want {
+			t.Fatalf("Describe gave %q instead of %q", got, want)
+		}
+		seen[want] = true
+
+		ForestFence(tile)
+		if got := hjkl.Describe(tile); got != "You see the wall." {
+			t.Fatalf("Describe gave %q for a fence", got)
+		}
+	}
+	if len(seen) != 2 {
+		t.Error("ForestTile failed to give both trees and grass")
+	}
+}
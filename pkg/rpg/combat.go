@@ -0,0 +1,49 @@
+package rpg
+
+import (
+	"github.com/jefflund/stones/pkg/hjkl"
+)
+
+// Evasion is an Event which gets the chance that a Mob evades an attack.
+type Evasion struct {
+	hjkl.Field[float64]
+}
+
+// Attack is published when a Mob attacks another, before the outcome is known.
+type Attack struct {
+	Attacker, Defender *hjkl.Mob
+}
+
+// Hit is published when an attack lands.
+type Hit struct {
+	Attacker, Defender *hjkl.Mob
+	Damage             int
+}
+
+func (h *Hit) String() string {
+	if h.Attacker == nil {
+		return hjkl.Log("%s <be> hurt", h.Defender)
+	}
+	return hjkl.Log("%s <hit> %o", h.Attacker, h.Defender)
+}
+
+// Miss is published when an attack is evaded.
+type Miss struct {
+	Attacker, Defender *hjkl.Mob
+}
+
+func (m *Miss) String() string {
+	return hjkl.Log("%s <miss> %o", m.Attacker, m.Defender)
+}
+
+// Kill is published when damage reduces a Mob to no Health.
+type Kill struct {
+	Attacker, Defender *hjkl.Mob
+}
+
+func (k *Kill) String() string {
+	if k.Attacker == nil {
+		return hjkl.Log("%s <die>", k.Defender)
+	}
+	return hjkl.Log("%s <kill> %o", k.Attacker, k.Defender)
+}
@@ -0,0 +1,305 @@
+package rpg
+
+import (
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/clock"
+)
+
+// Turn is the time taken by an ordinary action, which is the unit used for the
+// duration of a Status.
+const Turn = hjkl.CostWait
+
+// StatusKind identifies a Status, and doubles as the adjective for it, as in
+// "You are poisoned."
+type StatusKind string
+
+const (
+	StatusPoisoned     StatusKind = "poisoned"
+	StatusRegenerating StatusKind = "regenerating"
+	StatusHasted       StatusKind = "hasted"
+	StatusSlowed       StatusKind = "slowed"
+)
+
+// StackRule decides what happens when a Mob is afflicted with a Status it
+// already has.
+type StackRule int
+
+const (
+	// StackRefresh keeps the longer of the two durations.
+	StackRefresh StackRule = iota
+	// StackExtend adds the durations together.
+	StackExtend
+	// StackIntensify adds the magnitudes, and keeps the longer duration.
+	StackIntensify
+)
+
+// Status is a Component for a timed effect on a Mob. Every Period, a Status
+// pulses, such as poison doing damage, and once its Duration runs out it
+// expires and removes itself. A zero Period never pulses. Times are the same
+// as for the hjkl.Clock used by hjkl.Turns, so a Duration of 10*Turn lasts
+// for ten ordinary actions.
+//
+// A Status only pulses and expires once given to Effects with Apply.
+type Status struct {
+	Kind      StatusKind
+	Magnitude int
+	Duration  int
+	Period    int
+	Phase     int
+	Stack     StackRule
+}
+
+// Poison creates a Status which does damage every Turn.
+func Poison(damage, turns int) *Status {
+	return &Status{StatusPoisoned, damage, turns * Turn, Turn, 0, StackIntensify}
+}
+
+// Regeneration creates a Status which heals every so many turns.
+func Regeneration(amount, every, turns int) *Status {
+	return &Status{StatusRegenerating, amount, turns * Turn, every * Turn, 0, StackRefresh}
+}
+
+// Haste creates a Status which doubles speed.
+func Haste(turns int) *Status {
+	return &Status{StatusHasted, 0, turns * Turn, 0, 0, StackRefresh}
+}
+
+// Slow creates a Status which halves speed, though never below one.
+func Slow(turns int) *Status {
+	return &Status{StatusSlowed, 0, turns * Turn, 0, 0, StackExtend}
+}
+
+func (s *Status) Handle(e *hjkl.Mob, v hjkl.Event) {
+	switch v := v.(type) {
+	case *hjkl.Speed:
+		switch s.Kind {
+		case StatusHasted:
+			v.Value *= 2
+		case StatusSlowed:
+			// A speed of zero would be treated as NormalSpeed by hjkl.Delay.
+			v.Value = max(1, v.Value/2)
+		}
+	case *StatusQuery:
+		v.Value = append(v.Value, s)
+	}
+}
+
+// String gets the adjective for the Status.
+func (s *Status) String() string {
+	return string(s.Kind)
+}
+
+// pulse applies the periodic effect of the Status to a Mob.
+func (s *Status) pulse(m *hjkl.Mob) {
+	switch s.Kind {
+	case StatusPoisoned:
+		m.Handle(&Damage{nil, s.Magnitude})
+	case StatusRegenerating:
+		m.Handle(&Heal{s.Magnitude})
+	}
+}
+
+// merge stacks another Status of the same Kind into this one.
+func (s *Status) merge(o *Status) {
+	switch s.Stack {
+	case StackExtend:
+		s.Duration += o.Duration
+	case StackIntensify:
+		s.Magnitude += o.Magnitude
+		s.Duration = max(s.Duration, o.Duration)
+	default:
+		s.Duration = max(s.Duration, o.Duration)
+	}
+}
+
+// wait gets the time until the Status next needs attention, either to pulse
+// or to expire.
+func (s *Status) wait() int {
+	if s.Period <= 0 {
+		return s.Duration
+	}
+	return min(s.Period-s.Phase, s.Duration)
+}
+
+// Heal is an Event which restores Health, up to the maximum.
+type Heal struct {
+	Amount int
+}
+
+// StatusQuery is an Event which gets every Status on a Mob, so the UI can show
+// them.
+type StatusQuery struct {
+	hjkl.Field[[]*Status]
+}
+
+// StatusStart is published when a Mob gains a new Status.
+type StatusStart struct {
+	Mob    *hjkl.Mob
+	Status *Status
+}
+
+func (s *StatusStart) String() string {
+	return hjkl.Log("%s <be> %x", s.Mob, s.Status)
+}
+
+// StatusEnd is published when a Status expires.
+type StatusEnd struct {
+	Mob    *hjkl.Mob
+	Status *Status
+}
+
+func (s *StatusEnd) String() string {
+	return hjkl.Log("%s <be> no longer %x", s.Mob, s.Status)
+}
+
+// Effects schedules every applied Status on a Clock, so that each pulses and
+// expires on time. Effects should be added to the Systems of the Level, so
+// that Component can find it with LevelEffects, and Remove should be called
+// with any Mob which leaves the Level.
+type Effects struct {
+	Clock *clock.Clock[*Status]
+	mobs  map[*Status]*hjkl.Mob
+	waits map[*Status]int
+
+	// lag is the time which has passed since the Clock last advanced, which
+	// only advances when a Status comes due.
+	lag int
+}
+
+// NewEffects creates an Effects with nothing scheduled.
+func NewEffects() *Effects {
+	return &Effects{
+		Clock: clock.New[*Status](),
+		mobs:  make(map[*Status]*hjkl.Mob),
+		waits: make(map[*Status]int),
+	}
+}
+
+// Apply afflicts a Mob with a Status. If the Mob already has a Status of the
+// same Kind, the two are stacked according to the StackRule of the existing
+// Status. Otherwise, the Status is added as a Component and StatusStart is
+// published.
+func (e *Effects) Apply(m *hjkl.Mob, s *Status) {
+	for _, c := range m.Components {
+		if old, ok := c.(*Status); ok && old.Kind == s.Kind {
+			e.sync(old)
+			old.merge(s)
+			e.schedule(m, old, e.lag)
+			return
+		}
+	}
+	m.Components.Add(s)
+	e.schedule(m, s, e.lag)
+	m.Publish(&StatusStart{m, s})
+}
+
+// Track schedules any Status already attached to a Mob, such as after loading
+// a save. Since only whole pulses are saved, each Status restarts its current
+// wait.
+func (e *Effects) Track(m *hjkl.Mob) {
+	for _, c := range m.Components {
+		if s, ok := c.(*Status); ok {
+			e.schedule(m, s, e.lag)
+		}
+	}
+}
+
+// Remove forgets every Status of a Mob, without removing them from the Mob.
+func (e *Effects) Remove(m *hjkl.Mob) {
+	for s, owner := range e.mobs {
+		if owner == m {
+			e.Clock.Unschedule(s)
+			delete(e.mobs, s)
+			delete(e.waits, s)
+		}
+	}
+}
+
+// Advance lets the given time pass, pulsing and expiring each Status as it
+// comes due. The Clock skips straight from one due Status to the next, so
+// Advance takes no longer for a long time than a short one.
+func (e *Effects) Advance(time int) {
+	e.lag += time
+	for {
+		next, ok := e.Clock.Peek()
+		if !ok {
+			// Nothing is waiting on the time which has passed.
+			e.lag = 0
+			return
+		}
+		if delta, _ := e.Clock.Delta(next); delta > e.lag {
+			return
+		}
+		due, skipped := e.Clock.Advance()
+		e.lag -= skipped
+		for _, s := range due {
+			e.fire(s)
+		}
+	}
+}
+
+// LevelEffects gets the Effects in the Systems of the Level the Mob is on, or
+// nil if there is none.
+func LevelEffects(m *hjkl.Mob) *Effects {
+	if m.Pos == nil || m.Pos.Level() == nil {
+		return nil
+	}
+	for _, s := range m.Pos.Level().Systems {
+		if e, ok := s.(*Effects); ok {
+			return e
+		}
+	}
+	return nil
+}
+
+// schedule schedules the next time a Status needs attention, counting from
+// the given time after the Clock.
+func (e *Effects) schedule(m *hjkl.Mob, s *Status, after int) {
+	wait := max(1, s.wait())
+	e.mobs[s] = m
+	e.waits[s] = wait
+	e.Clock.Schedule(s, wait+after)
+}
+
+// sync brings the Duration and Phase of a scheduled Status up to date with the
+// time which has passed since it was scheduled.
+func (e *Effects) sync(s *Status) {
+	delta, ok := e.Clock.Delta(s)
+	if !ok {
+		return
+	}
+	remaining := delta - e.lag
+	elapsed := e.waits[s] - remaining
+	s.Duration -= elapsed
+	s.Phase += elapsed
+	e.waits[s] = remaining
+}
+
+// fire handles a Status which has come due.
+func (e *Effects) fire(s *Status) {
+	// A Status popped alongside another may have lost its Mob since.
+	m, ok := e.mobs[s]
+	if !ok {
+		return
+	}
+	s.Duration -= e.waits[s]
+	s.Phase += e.waits[s]
+	if s.Period > 0 && s.Phase >= s.Period {
+		s.Phase = 0
+		s.pulse(m)
+	}
+
+	// The pulse may have killed the Mob, which forgets the Status.
+	if _, ok := e.mobs[s]; !ok {
+		return
+	}
+	// The Status came due just as the Clock advanced, so it has no lag.
+	if s.Duration > 0 {
+		e.schedule(m, s, 0)
+		return
+	}
+	delete(e.mobs, s)
+	delete(e.waits, s)
+	m.Components.Remove(s)
+	m.Publish(&StatusEnd{m, s})
+}
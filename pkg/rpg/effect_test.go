@@ -0,0 +1,191 @@
+package rpg
+
+import (
+	"testing"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+)
+
+// newTestEffects adds Effects to the Level, which forget any Mob removed from
+// the Level.
+func newTestEffects(level *hjkl.Level) *Effects {
+	effects := NewEffects()
+	level.Systems = append(level.Systems, effects)
+	level.Mobs.OnRemove = append(level.Mobs.OnRemove, effects.Remove)
+	return effects
+}
+
+// hasStatus determines whether the Mob has a Status of the given Kind.
+func hasStatus(m *hjkl.Mob, kind StatusKind) bool {
+	for _, s := range hjkl.Get(m, &StatusQuery{}) {
+		if s.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEffects_Expire(t *testing.T) {
+	level := newTestLevel(3, 3)
+	effects := newTestEffects(level)
+	m, c := newTestMob(level, hjkl.Vec(1, 1), 10)
+	var events []hjkl.Event
+	level.Events = hjkl.SinkFunc(func(v hjkl.Event) {
+		events = append(events, v)
+	})
+
+	poison := Poison(1, 3)
+	effects.Apply(m, poison)
+	if len(events) != 1 || *events[0].(*StatusStart) != (StatusStart{m, poison}) {
+		t.Fatal("Effects.Apply failed to publish StatusStart")
+	}
+	effects.Advance(Turn)
+	if c.Health != 9 {
+		t.Errorf("Poison pulsed to %d Health instead of 9", c.Health)
+	}
+	events = nil
+	effects.Advance(2*Turn + Turn/2)
+	if c.Health != 7 {
+		t.Errorf("Poison pulsed to %d Health instead of 7", c.Health)
+	}
+	if hasStatus(m, StatusPoisoned) {
+		t.Error("Poison failed to expire")
+	}
+	end, ok := events[len(events)-1].(*StatusEnd)
+	if !ok || *end != (StatusEnd{m, poison}) {
+		t.Error("Poison failed to publish StatusEnd")
+	}
+	if effects.Clock.Len() != 0 {
+		t.Error("Poison stayed on the Clock after expiring")
+	}
+}
+
+func TestEffects_Refresh(t *testing.T) {
+	level := newTestLevel(3, 3)
+	effects := newTestEffects(level)
+	m, _ := newTestMob(level, hjkl.Vec(1, 1), 10)
+
+	effects.Apply(m, Haste(3))
+	effects.Advance(Turn)
+	// With 2 turns left, the longer 5 turns wins.
+	effects.Apply(m, Haste(5))
+	effects.Advance(5*Turn - 1)
+	if !hasStatus(m, StatusHasted) {
+		t.Fatal("Haste failed to refresh")
+	}
+	effects.Advance(1)
+	if hasStatus(m, StatusHasted) {
+		t.Error("Haste failed to expire after refreshing")
+	}
+}
+
+func TestEffects_Extend(t *testing.T) {
+	level := newTestLevel(3, 3)
+	effects := newTestEffects(level)
+	m, _ := newTestMob(level, hjkl.Vec(1, 1), 10)
+
+	effects.Apply(m, Slow(3))
+	effects.Advance(Turn)
+	// The 2 turns left are extended by another 2 turns.
+	effects.Apply(m, Slow(2))
+	effects.Advance(4*Turn - 1)
+	if !hasStatus(m, StatusSlowed) {
+		t.Fatal("Slow failed to extend")
+	}
+	effects.Advance(1)
+	if hasStatus(m, StatusSlowed) {
+		t.Error("Slow failed to expire after extending")
+	}
+}
+
+func TestEffects_Intensify(t *testing.T) {
+	level := newTestLevel(3, 3)
+	effects := newTestEffects(level)
+	m, c := newTestMob(level, hjkl.Vec(1, 1), 10)
+
+	effects.Apply(m, Poison(1, 3))
+	effects.Advance(Turn / 2)
+	// Halfway to the first pulse, which still comes on time but harder.
+	effects.Apply(m, Poison(2, 2))
+	effects.Advance(Turn / 2)
+	if c.Health != 7 {
+		t.Errorf("Poison pulsed to %d Health instead of 7", c.Health)
+	}
+	effects.Advance(2 * Turn)
+	if c.Health != 1 {
+		t.Errorf("Poison pulsed to %d Health instead of 1", c.Health)
+	}
+	if hasStatus(m, StatusPoisoned) {
+		t.Error("Poison failed to expire after intensifying")
+	}
+}
+
+func TestEffects_Regeneration(t *testing.T) {
+	level := newTestLevel(3, 3)
+	effects := newTestEffects(level)
+	m, c := newTestMob(level, hjkl.Vec(1, 1), 5)
+
+	// Heals every 2 turns for 5 turns, so the last turn never pulses.
+	effects.Apply(m, Regeneration(1, 2, 5))
+	effects.Advance(4 * Turn)
+	if c.Health != 7 {
+		t.Errorf("Regeneration healed to %d Health instead of 7", c.Health)
+	}
+	effects.Advance(Turn - 1)
+	if !hasStatus(m, StatusRegenerating) {
+		t.Fatal("Regeneration expired early")
+	}
+	effects.Advance(1)
+	if hasStatus(m, StatusRegenerating) {
+		t.Error("Regeneration failed to expire")
+	}
+	if c.Health != 7 {
+		t.Errorf("Regeneration healed to %d Health instead of 7", c.Health)
+	}
+}
+
+func TestEffects_Kill(t *testing.T) {
+	level := newTestLevel(3, 3)
+	effects := newTestEffects(level)
+	m, _ := newTestMob(level, hjkl.Vec(1, 1), 1)
+
+	// Both come due together, but the Poison kills the Mob first.
+	effects.Apply(m, Poison(1, 1))
+	effects.Apply(m, Regeneration(1, 1, 1))
+	effects.Advance(Turn)
+	if level.Mobs.Contains(m) {
+		t.Fatal("Poison failed to kill")
+	}
+	if !hasStatus(m, StatusRegenerating) {
+		t.Error("Effects expired Regeneration on a dead Mob")
+	}
+	if effects.Clock.Len() != 0 || len(effects.mobs) != 0 {
+		t.Error("Effects failed to forget a dead Mob")
+	}
+}
+
+func TestStatus_Speed(t *testing.T) {
+	level := newTestLevel(3, 3)
+	effects := newTestEffects(level)
+	cases := []struct {
+		Speed  int
+		Status []*Status
+		Delay  int
+	}{
+		{0, nil, hjkl.CostMove},
+		{0, []*Status{Haste(1)}, hjkl.CostMove / 2},
+		{0, []*Status{Slow(1)}, hjkl.CostMove * 2},
+		{0, []*Status{Haste(1), Slow(1)}, hjkl.CostMove},
+		{1, []*Status{Slow(1)}, hjkl.CostMove * hjkl.NormalSpeed},
+	}
+	for i, c := range cases {
+		m, char := newTestMob(level, hjkl.Vec(i%3, i/3), 10)
+		char.Speed = c.Speed
+		for _, s := range c.Status {
+			effects.Apply(m, s)
+		}
+		if got := hjkl.Delay(m, hjkl.CostMove); got != c.Delay {
+			t.Errorf("case %d gave Delay %d instead of %d", i, got, c.Delay)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package rpg
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jefflund/stones/pkg/hjkl"
+)
+
+// Pronoun is the grammatical gender used to refer to a Mob.
+type Pronoun int
+
+const (
+	PronounIt Pronoun = iota
+	PronounHe
+	PronounShe
+	PronounThey
+)
+
+// Reflexive gets the reflexive form of the Pronoun, e.g., "herself".
+func (p Pronoun) Reflexive() string {
+	switch p {
+	case PronounHe:
+		return "himself"
+	case PronounShe:
+		return "herself"
+	case PronounThey:
+		return "themself"
+	default:
+		return "itself"
+	}
+}
+
+// Name is a Component which answers hjkl.NameQuery and hjkl.ReflexiveQuery so
+// that a Mob can be used with hjkl.Log. Proper names are capitalized, which
+// tells Log not to add an article. The hero is simply named "you".
+type Name struct {
+	Singular string
+	Plural   string
+	Proper   bool
+	Pronoun  Pronoun
+}
+
+func (n *Name) Handle(e *hjkl.Mob, v hjkl.Event) {
+	switch v := v.(type) {
+	case *hjkl.NameQuery:
+		v.Value = n.String()
+	case *hjkl.ReflexiveQuery:
+		v.Value = n.Pronoun.Reflexive()
+	}
+}
+
+// String gets the singular name, capitalized if it is a proper name.
+func (n *Name) String() string {
+	if !n.Proper {
+		return n.Singular
+	}
+	r, size := utf8.DecodeRuneInString(n.Singular)
+	return string(unicode.ToUpper(r)) + n.Singular[size:]
+}
+
+// Count gets a noun phrase for some number of the named Mob, e.g., "a bear" or
+// "3 bears". If there is no Plural, the Singular with an "s" is used instead.
+func (n *Name) Count(k int) string {
+	if n.Proper || n.Singular == "" {
+		return n.String()
+	}
+	if k == 1 {
+		if strings.ContainsRune("aeiou", rune(n.Singular[0])) {
+			return "an " + n.Singular
+		}
+		return "a " + n.Singular
+	}
+	plural := n.Plural
+	if plural == "" {
+		plural = n.Singular + "s"
+	}
+	return strconv.Itoa(k) + " " + plural
+}
@@ -1,15 +1,35 @@
 // Package rpg provides the rpg mechanics for stones.
 package rpg
 
-import "github.com/jefflund/stones/pkg/hjkl"
+import (
+	"github.com/jefflund/stones/pkg/hjkl"
+	"github.com/jefflund/stones/pkg/hjkl/rand"
+	"github.com/jefflund/stones/pkg/hjkl/save"
+)
+
+func init() {
+	save.Register[Character]("rpg.Character")
+	save.Register[Name]("rpg.Name")
+	save.Register[Status]("rpg.Status")
+	save.Register[AI]("rpg.AI")
+	save.Register[Terrain]("rpg.Terrain")
+}
+
+// CorpseCh is the rune for a corpse, which is distinct from anything in the
+// forest so corpses stand out.
+const CorpseCh = '&'
 
 type Damage struct {
+	Source *hjkl.Mob
 	Amount int
 }
 
 type Attributes struct {
 	MaxHealth int
 	Damage    int
+	Evasion   float64
+	Speed     int
+	Venom     int
 }
 
 type Variables struct {
@@ -23,13 +43,42 @@ type Character struct {
 
 func (c *Character) Handle(e *hjkl.Mob, v hjkl.Event) {
 	switch v := v.(type) {
+	case *Evasion:
+		v.Value = c.Evasion
+	case *hjkl.Speed:
+		if c.Speed > 0 {
+			v.Value = c.Speed
+		}
 	case *hjkl.Bump:
-		v.Bumped.Handle(&Damage{c.Damage})
+		e.Publish(&Attack{e, v.Bumped})
+		if evasion := hjkl.Get(v.Bumped, &Evasion{}); evasion > 0 && rand.Chance(evasion) {
+			e.Publish(&Miss{e, v.Bumped})
+			return
+		}
+		v.Bumped.Handle(&Damage{e, c.Damage})
+		// Venom poisons whatever survives the hit.
+		if effects := LevelEffects(v.Bumped); c.Venom > 0 && effects != nil {
+			effects.Apply(v.Bumped, Poison(c.Venom, 3))
+		}
 	case *Damage:
+		if c.Health <= 0 {
+			return
+		}
 		c.Health -= v.Amount
+		e.Publish(&Hit{v.Source, e, v.Amount})
 		if c.Health <= 0 {
-			e.Pos.Occupant = nil
-			e.Pos = nil
+			e.Publish(&Kill{v.Source, e})
+			hjkl.Kill(e, v.Source)
+		}
+	case *Heal:
+		if c.Health > 0 {
+			c.Health = min(c.MaxHealth, c.Health+v.Amount)
+		}
+	case *hjkl.Death:
+		// Leave a corpse behind on the Tile where the Mob died.
+		if e.Pos != nil {
+			e.Pos.Face = hjkl.ChFg(CorpseCh, e.Face.Fg)
+			SetTerrain(e.Pos, "corpse")
 		}
 	}
 }